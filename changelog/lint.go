@@ -0,0 +1,94 @@
+package changelog
+
+import (
+	"fmt"
+
+	"github.com/nothub/semver"
+)
+
+// Problem is a single finding of Lint.
+type Problem struct {
+	// 1-based line number, 0 for findings about the whole document
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// Lint checks a changelog for:
+//
+//   - a missing Unreleased section, or one that is not on top
+//   - headings that are no valid semver
+//   - duplicate versions
+//   - versions that are not in descending order under semver.Compare
+//
+// If tags is not nil, releases without changelog entry and
+// entries without tag are reported too.
+func Lint(c Changelog, tags []semver.Version) []Problem {
+	var problems []Problem
+	report := func(line int, format string, a ...any) {
+		problems = append(problems, Problem{Line: line, Message: fmt.Sprintf(format, a...)})
+	}
+
+	unreleased := false
+	seen := make(map[string]int)
+	var prev *Entry
+	for i := range c.Entries {
+		e := &c.Entries[i]
+		switch {
+		case e.Unreleased:
+			if unreleased {
+				report(e.Line, "duplicate Unreleased section")
+			} else if prev != nil {
+				report(e.Line, "Unreleased section is not on top")
+			}
+			unreleased = true
+			continue
+		case e.Err != nil:
+			report(e.Line, "invalid version heading: %s", e.Err)
+			continue
+		}
+
+		ver := e.Version.String()
+		if line, ok := seen[ver]; ok {
+			report(e.Line, "duplicate version %s, first seen on line %d", ver, line)
+		} else {
+			seen[ver] = e.Line
+		}
+		if prev != nil && !e.Version.Older(prev.Version) && ver != prev.Version.String() {
+			report(e.Line, "version %s is not older than %s on line %d", ver, prev.Version.String(), prev.Line)
+		}
+		prev = e
+	}
+	if !unreleased {
+		report(0, "missing Unreleased section")
+	}
+
+	if tags != nil {
+		tagged := make(map[string]bool)
+		for _, tag := range tags {
+			tagged[tag.String()] = true
+			if !tag.IsRelease() {
+				continue
+			}
+			if _, ok := c.Lookup(tag); !ok {
+				report(0, "release %s is tagged but has no changelog entry", tag.String())
+			}
+		}
+		for _, e := range c.Entries {
+			if e.Unreleased || e.Err != nil {
+				continue
+			}
+			if !tagged[e.Version.String()] {
+				report(e.Line, "version %s has no git tag", e.Version.String())
+			}
+		}
+	}
+
+	return problems
+}
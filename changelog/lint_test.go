@@ -0,0 +1,61 @@
+package changelog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		tags     []semver.Version
+		expected []string
+	}{
+		{
+			name:     "clean",
+			doc:      "# Changelog\n\n## [Unreleased]\n\n## [1.1.0]\n\n## [1.0.0]\n",
+			tags:     semver.MustParseAll([]string{"1.0.0", "1.1.0-rc.1", "1.1.0"}),
+			expected: nil,
+		},
+		{
+			name: "ordering, duplicates and invalid versions",
+			doc:  "# Changelog\n\n## [1.0.0]\n\n## [1.2.0]\n\n## [1.2.0]\n\n## [1.1]\n\n## [Unreleased]\n",
+			expected: []string{
+				"line 5: version 1.2.0 is not older than 1.0.0 on line 3",
+				"line 7: duplicate version 1.2.0, first seen on line 5",
+				"line 9: invalid version heading: invalid semver string: \"1.1\"",
+				"line 11: Unreleased section is not on top",
+			},
+		},
+		{
+			name: "missing unreleased",
+			doc:  "# Changelog\n\n## [1.0.0]\n",
+			expected: []string{
+				"missing Unreleased section",
+			},
+		},
+		{
+			name: "gaps to git tags",
+			doc:  "# Changelog\n\n## [Unreleased]\n\n## [1.2.0]\n\n## [1.0.0]\n",
+			tags: semver.MustParseAll([]string{"1.0.0", "1.1.0"}),
+			expected: []string{
+				"release 1.1.0 is tagged but has no changelog entry",
+				"line 5: version 1.2.0 has no git tag",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range Lint(Parse(tt.doc), tt.tags) {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(tt.expected, got) {
+				t.Errorf("unexpected problems:\nexpected = %q\nactual   = %q", tt.expected, got)
+			}
+		})
+	}
+}
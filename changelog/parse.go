@@ -0,0 +1,93 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// Changelog is a parsed Keep a Changelog document.
+type Changelog struct {
+	// level 2 sections in document order
+	Entries []Entry
+}
+
+// Entry is a level 2 section of a changelog.
+//
+//	## [1.0.0] - 2024-01-01
+type Entry struct {
+	// 1-based line number of the heading
+	Line    int
+	Heading string
+	// heading reads "Unreleased"
+	Unreleased bool
+	// zero for unreleased sections and invalid headings
+	Version semver.Version
+	// reason the heading is no valid version, e.g. semver.ErrInvalid
+	Err  error
+	Date string
+	// content between this heading and the next one
+	Body string
+}
+
+// Parse reads a Keep a Changelog document into entries.
+// Headings that are no valid semver are kept with Err set.
+func Parse(doc string) Changelog {
+	lines := strings.Split(doc, "\n")
+	end := endOfSections(lines)
+
+	var c Changelog
+	for i, line := range lines[:end] {
+		text, ok := strings.CutPrefix(line, "## ")
+		if !ok {
+			if n := len(c.Entries); n > 0 {
+				c.Entries[n-1].Body += line + "\n"
+			}
+			continue
+		}
+
+		e := Entry{Line: i + 1, Heading: strings.TrimSpace(text)}
+		label, date, _ := strings.Cut(e.Heading, " - ")
+		label = strings.TrimSpace(label)
+		label = strings.TrimPrefix(label, "[")
+		label, _, _ = strings.Cut(label, "]")
+		label = strings.TrimSpace(label)
+		e.Date = strings.TrimSpace(date)
+		if strings.EqualFold(label, "unreleased") {
+			e.Unreleased = true
+		} else {
+			e.Version, e.Err = semver.Parse(strings.TrimPrefix(label, "v"))
+			if e.Err != nil {
+				e.Err = fmt.Errorf("%w: %q", e.Err, label)
+			}
+		}
+		c.Entries = append(c.Entries, e)
+	}
+	for i := range c.Entries {
+		c.Entries[i].Body = strings.Trim(c.Entries[i].Body, "\n")
+	}
+
+	return c
+}
+
+// Lookup returns the entry of a version.
+func (c *Changelog) Lookup(ver semver.Version) (Entry, bool) {
+	for _, e := range c.Entries {
+		if e.Err == nil && !e.Unreleased && e.Version.String() == ver.String() {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Versions returns the versions of all valid entries in document order.
+func (c *Changelog) Versions() []semver.Version {
+	var vers []semver.Version
+	for _, e := range c.Entries {
+		if e.Err == nil && !e.Unreleased {
+			vers = append(vers, e.Version)
+		}
+	}
+	return vers
+}
@@ -0,0 +1,63 @@
+package changelog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func TestParse(t *testing.T) {
+	c := Parse(`# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-01-03
+
+### Added
+
+- something
+
+## v1.0.0
+
+- initial
+
+## [1.0] - 2023-12-24
+
+[1.1.0]: https://example.org/compare/v1.0.0...v1.1.0
+`)
+
+	if len(c.Entries) != 4 {
+		t.Fatalf("unexpected entry count: %d", len(c.Entries))
+	}
+
+	if e := c.Entries[0]; !e.Unreleased || e.Line != 3 {
+		t.Errorf("unexpected unreleased entry: %+v", e)
+	}
+
+	e := c.Entries[1]
+	if e.Version.String() != "1.1.0" || e.Date != "2024-01-03" || e.Line != 5 || e.Err != nil {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e.Body != "### Added\n\n- something" {
+		t.Errorf("unexpected body: %q", e.Body)
+	}
+
+	if e := c.Entries[2]; e.Version.String() != "1.0.0" || e.Date != "" || e.Body != "- initial" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+
+	if e := c.Entries[3]; !errors.Is(e.Err, semver.ErrInvalid) || e.Body != "" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+
+	if e, ok := c.Lookup(semver.MustParse("1.0.0")); !ok || e.Line != 11 {
+		t.Errorf("unexpected lookup: %+v, %v", e, ok)
+	}
+	if _, ok := c.Lookup(semver.MustParse("2.0.0")); ok {
+		t.Error("should not find 2.0.0")
+	}
+	if vers := c.Versions(); len(vers) != 2 {
+		t.Errorf("unexpected versions: %v", vers)
+	}
+}
@@ -0,0 +1,164 @@
+// Package changelog renders and edits changelogs following
+// the Keep a Changelog format (https://keepachangelog.com/).
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/conventional"
+)
+
+// Change groups in rendering order.
+const (
+	Breaking = "Breaking"
+	Added    = "Added"
+	Changed  = "Changed"
+	Fixed    = "Fixed"
+)
+
+var groups = []string{Breaking, Added, Changed, Fixed}
+
+var ErrExists = errors.New("version already exists")
+
+// Section holds the changes of one released version.
+type Section struct {
+	Version semver.Version
+	// release date, omitted if zero
+	Date time.Time
+	// entries by group name
+	Groups map[string][]string
+}
+
+// FromCommits groups conventional commits into a Section.
+// Breaking changes go to Breaking, features to Added, fixes to Fixed
+// and performance improvements and refactorings to Changed.
+// Commits of all other types are omitted.
+func FromCommits(ver semver.Version, date time.Time, commits []conventional.Commit) Section {
+	s := Section{Version: ver, Date: date, Groups: make(map[string][]string)}
+	for _, c := range commits {
+		var group string
+		switch {
+		case c.Breaking:
+			group = Breaking
+		case c.Type == "feat":
+			group = Added
+		case c.Type == "fix":
+			group = Fixed
+		case c.Type == "perf" || c.Type == "refactor":
+			group = Changed
+		default:
+			continue
+		}
+		entry := c.Description
+		if c.Scope != "" {
+			entry = "**" + c.Scope + ":** " + entry
+		}
+		s.Groups[group] = append(s.Groups[group], entry)
+	}
+	return s
+}
+
+// Markdown renders the Section.
+//
+//	## [1.2.0] - 2024-01-05
+//
+//	### Added
+//
+//	- **api:** new endpoint
+func (s *Section) Markdown() string {
+	sb := strings.Builder{}
+	sb.WriteString("## [")
+	sb.WriteString(s.Version.String())
+	sb.WriteString("]")
+	if !s.Date.IsZero() {
+		sb.WriteString(" - ")
+		sb.WriteString(s.Date.Format(time.DateOnly))
+	}
+	sb.WriteString("\n")
+	for _, group := range groups {
+		entries := s.Groups[group]
+		if len(entries) == 0 {
+			continue
+		}
+		sb.WriteString("\n### ")
+		sb.WriteString(group)
+		sb.WriteString("\n\n")
+		for _, entry := range entries {
+			sb.WriteString("- ")
+			sb.WriteString(entry)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// Insert adds the Section to a changelog document, in front of the first
+// version heading that is older under semver.Compare. Sections newer than
+// all existing versions end up right below the Unreleased section.
+//
+// Insert might return changelog.ErrExists.
+func Insert(doc string, s Section) (string, error) {
+	if strings.TrimSpace(doc) == "" {
+		doc = "# Changelog\n"
+	}
+	lines := strings.Split(doc, "\n")
+
+	at := -1
+	c := Parse(doc)
+	for _, e := range c.Entries {
+		if e.Unreleased || e.Err != nil {
+			continue
+		}
+		switch semver.Compare(e.Version, s.Version) {
+		case 0:
+			return "", fmt.Errorf("%w: %s", ErrExists, s.Version.String())
+		case -1:
+			if at == -1 {
+				at = e.Line - 1
+			}
+		}
+	}
+	if at == -1 {
+		at = endOfSections(lines)
+	}
+
+	section := strings.Split(s.Markdown(), "\n")
+	var out []string
+	out = append(out, lines[:at]...)
+	if at > 0 && strings.TrimSpace(lines[at-1]) != "" {
+		out = append(out, "")
+	}
+	out = append(out, section...)
+	out = append(out, lines[at:]...)
+
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n", nil
+}
+
+// endOfSections returns the line in front of the trailing link reference
+// definitions, or the end of the document if there are none.
+func endOfSections(lines []string) int {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	refs := end
+	for refs > 0 && (isLinkRef(lines[refs-1]) || strings.TrimSpace(lines[refs-1]) == "") {
+		refs--
+	}
+	if refs < end {
+		// keep the blank line in front of the definitions
+		for refs < end && strings.TrimSpace(lines[refs]) == "" {
+			refs++
+		}
+		return refs
+	}
+	return end
+}
+
+func isLinkRef(line string) bool {
+	return strings.HasPrefix(line, "[") && strings.Contains(line, "]: ")
+}
@@ -0,0 +1,200 @@
+package changelog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/conventional"
+)
+
+func TestFromCommits(t *testing.T) {
+	var commits []conventional.Commit
+	for _, msg := range []string{
+		"feat(api): new endpoint",
+		"fix: off by one",
+		"docs: readme",
+		"perf: faster parsing",
+		"feat!: drop legacy flag",
+	} {
+		c, err := conventional.Parse(msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		commits = append(commits, c)
+	}
+
+	s := FromCommits(semver.MustParse("1.2.0"), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), commits)
+	expected := `## [1.2.0] - 2024-01-05
+
+### Breaking
+
+- drop legacy flag
+
+### Added
+
+- **api:** new endpoint
+
+### Changed
+
+- faster parsing
+
+### Fixed
+
+- off by one
+`
+	if s.Markdown() != expected {
+		t.Errorf("unexpected markdown:\nexpected = %q\nactual   = %q", expected, s.Markdown())
+	}
+}
+
+const testDoc = `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-01-03
+
+### Added
+
+- something
+
+## [1.0.0] - 2024-01-01
+
+- initial
+
+[1.1.0]: https://example.org/compare/v1.0.0...v1.1.0
+[1.0.0]: https://example.org/releases/v1.0.0
+`
+
+func TestInsert(t *testing.T) {
+	section := func(ver string) Section {
+		return Section{
+			Version: semver.MustParse(ver),
+			Groups:  map[string][]string{Fixed: {"fix " + ver}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		doc      string
+		section  Section
+		expected string
+	}{
+		{
+			name:    "newest",
+			doc:     testDoc,
+			section: section("1.2.0"),
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0]
+
+### Fixed
+
+- fix 1.2.0
+
+## [1.1.0] - 2024-01-03
+
+### Added
+
+- something
+
+## [1.0.0] - 2024-01-01
+
+- initial
+
+[1.1.0]: https://example.org/compare/v1.0.0...v1.1.0
+[1.0.0]: https://example.org/releases/v1.0.0
+`,
+		},
+		{
+			name:    "backport",
+			doc:     testDoc,
+			section: section("1.0.1"),
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-01-03
+
+### Added
+
+- something
+
+## [1.0.1]
+
+### Fixed
+
+- fix 1.0.1
+
+## [1.0.0] - 2024-01-01
+
+- initial
+
+[1.1.0]: https://example.org/compare/v1.0.0...v1.1.0
+[1.0.0]: https://example.org/releases/v1.0.0
+`,
+		},
+		{
+			name:    "oldest",
+			doc:     testDoc,
+			section: section("0.9.0"),
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-01-03
+
+### Added
+
+- something
+
+## [1.0.0] - 2024-01-01
+
+- initial
+
+## [0.9.0]
+
+### Fixed
+
+- fix 0.9.0
+
+[1.1.0]: https://example.org/compare/v1.0.0...v1.1.0
+[1.0.0]: https://example.org/releases/v1.0.0
+`,
+		},
+		{
+			name:    "empty document",
+			doc:     "",
+			section: section("0.1.0"),
+			expected: `# Changelog
+
+## [0.1.0]
+
+### Fixed
+
+- fix 0.1.0
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Insert(tt.doc, tt.section)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.expected {
+				t.Errorf("unexpected document:\nexpected = %q\nactual   = %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInsertExists(t *testing.T) {
+	_, err := Insert(testDoc, Section{Version: semver.MustParse("1.1.0")})
+	if !errors.Is(err, ErrExists) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/conventional"
+)
+
+// nextAuto determines the next version from conventional commit messages,
+// read from a git revision range or stdin. Stdin holds a single message,
+// or NUL separated messages with --null. The decision is explained on stderr.
+func nextAuto(args []string, stdin io.Reader, stderr io.Writer) (result, error) {
+	fs := newFlagSet("next auto")
+	repo := fs.String("repo", ".", "")
+	rng := fs.String("git", "", "")
+	null := fs.Bool("null", false, "")
+	fs.BoolVar(null, "z", false, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) != 1 {
+		return result{}, errUsage
+	}
+
+	ver, err := semver.Parse(args[0])
+	if err != nil {
+		return result{}, err
+	}
+
+	var msgs []string
+	if *rng != "" {
+		log, err := gitLog(*repo, *rng)
+		if err != nil {
+			return result{}, err
+		}
+		for _, c := range log {
+			msgs = append(msgs, c.Message)
+		}
+	} else {
+		msgs, err = readMessages(stdin, *null)
+		if err != nil {
+			return result{}, err
+		}
+	}
+
+	var commits []conventional.Commit
+	for _, msg := range msgs {
+		c, err := conventional.Parse(msg)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, c)
+	}
+
+	next, bump, drivers := conventional.Next(ver, commits)
+	if bump == conventional.None {
+		_, _ = fmt.Fprintf(stderr, "no release relevant commits in %d messages\n", len(msgs))
+	} else {
+		_, _ = fmt.Fprintf(stderr, "%s bump from %s to %s driven by:\n", bump, ver.String(), next.String())
+		for _, c := range drivers {
+			_, _ = fmt.Fprintf(stderr, "  %s\n", c.Header())
+		}
+	}
+
+	return versionResult(next), nil
+}
+
+// readMessages reads commit messages from input. The input is a single message,
+// or messages separated by NUL bytes (git log --format=%B%x00) if null is set.
+func readMessages(r io.Reader, null bool) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	all := []string{string(data)}
+	if null {
+		all = strings.Split(string(data), "\x00")
+	}
+	var msgs []string
+	for _, msg := range all {
+		if strings.TrimSpace(msg) != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
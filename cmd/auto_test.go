@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_nextAuto(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		explain string
+		wantErr bool
+	}{
+		{
+			name:    "single message",
+			args:    []string{"1.2.3"},
+			stdin:   "feat(api): b\n\nbody\n",
+			want:    "1.3.0",
+			explain: "minor bump from 1.2.3 to 1.3.0 driven by:\n  feat(api): b\n",
+		},
+		{
+			name:    "lines are no separate messages",
+			args:    []string{"1.2.3"},
+			stdin:   "fix: a\nfeat: b\n",
+			want:    "1.2.4",
+			explain: "patch bump from 1.2.3 to 1.2.4 driven by:\n  fix: a\n",
+		},
+		{
+			name:    "nul separated messages",
+			args:    []string{"--null", "1.2.3"},
+			stdin:   "fix: a\n\nBREAKING CHANGE: b\x00feat: c\n\nbody\x00",
+			want:    "2.0.0",
+			explain: "major bump from 1.2.3 to 2.0.0 driven by:\n  fix!: a\n",
+		},
+		{
+			name:    "no relevant commits",
+			args:    []string{"-z", "1.2.3"},
+			stdin:   "docs: a\x00not conventional\x00",
+			want:    "1.2.3",
+			explain: "no release relevant commits in 2 messages\n",
+		},
+		{
+			name:    "invalid version",
+			args:    []string{"-0.0.0"},
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			args:    []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stderr := &bytes.Buffer{}
+			got, err := nextAuto(tt.args, strings.NewReader(tt.stdin), stderr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("nextAuto() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("nextAuto() got = %v, want %v", got.text, tt.want)
+			}
+			if stderr.String() != tt.explain {
+				t.Errorf("nextAuto() explain = %q, want %q", stderr.String(), tt.explain)
+			}
+		})
+	}
+}
+
+func Test_nextAutoGit(t *testing.T) {
+	dir := gitRepo(t,
+		[]string{"commit", "-q", "--allow-empty", "-m", "feat: one"},
+		[]string{"tag", "v0.1.0"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "fix: two"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "feat: three"},
+	)
+	got, err := nextAuto([]string{"--repo", dir, "--git", "v0.1.0..HEAD", "0.1.0"}, strings.NewReader(""), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("nextAuto() error = %v", err)
+	}
+	if got.text != "0.1.1" {
+		t.Errorf("nextAuto() got = %v, want %v", got.text, "0.1.1")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// backport lists the release branches receiving a fix of a bug and their next patch version.
+// The released versions are read from args following the introducing version, or from stdin if there are none.
+func backport(args []string, stdin io.Reader) (result, error) {
+	fs := newFlagSet("backport")
+	var policy semver.SupportPolicy
+	fs.IntVar(&policy.Majors, "majors", -1, "")
+	fs.IntVar(&policy.Minors, "minors", -1, "")
+	branch := fs.String("branch", "release/{major}.{minor}", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) < 1 {
+		return result{}, errUsage
+	}
+
+	introduced, err := semver.Parse(args[0])
+	if err != nil {
+		return result{}, fmt.Errorf("%w: %q", err, args[0])
+	}
+	vers, err := readVersions(args[1:], stdin)
+	if err != nil {
+		return result{}, err
+	}
+
+	var lines []string
+	backports := []backportJSON{}
+	for _, b := range policy.Backports(vers, introduced) {
+		name := strings.NewReplacer("{major}", strconv.Itoa(b.Line.Major), "{minor}", strconv.Itoa(b.Line.Minor)).Replace(*branch)
+		lines = append(lines, name+" "+b.Next.String())
+		backports = append(backports, backportJSON{Branch: name, Next: newVersionJSON(b.Next)})
+	}
+	return result{text: joinLines(lines), value: backports}, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_backport(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "all lines",
+			args: []string{"1.2.5", "1.3.1", "1.2.7", "1.1.9", "1.3.0"},
+			want: "release/1.3 1.3.2\nrelease/1.2 1.2.8\n",
+		},
+		{
+			name:  "policy from stdin",
+			args:  []string{"--majors", "1", "--minors", "1", "--branch", "v{major}.{minor}.x", "1.2.5"},
+			stdin: "2.0.0\n1.3.1\n1.2.7\n",
+			want:  "v2.0.x 2.0.1\n",
+		},
+		{
+			name: "not affected",
+			args: []string{"2.0.0", "1.3.1"},
+			want: "",
+		},
+		{
+			name:    "invalid introduced version",
+			args:    []string{"1.2", "1.3.1"},
+			wantErr: true,
+		},
+		{
+			name:    "missing introduced version",
+			args:    []string{"--majors", "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := backport(tt.args, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("backport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("backport() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
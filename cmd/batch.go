@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// batchArgs holds the count of fixed arguments of the commands processing
+// multiple versions, e.g. the mode of next.
+var batchArgs = map[string]int{
+	"next":  1,
+	"strip": 1,
+	"get":   1,
+	"set":   2,
+	"valid": 0,
+	"tags":  0,
+}
+
+// batch applies fn to every input of a command and returns one result per line,
+// each terminated by a newline, silent results are omitted.
+// The value is the value of a single input or an array holding an entry of every
+// processed input, including the failed ones.
+// The inputs are the arguments following the fixed arguments, or the lines of stdin for "-".
+// Without --keep-going the first failure is returned. With --keep-going failures are
+// reported on stderr and a summary of all failures is returned along with the results.
+//
+// The flag set holds the flags of the command, it is named after the command.
+// If init is set, it is called with the positional arguments after the flags are parsed.
+func batch(fs *flag.FlagSet, args []string, e env, init func(args []string) error, fn func(fixed []string, str string) (result, error)) (result, error) {
+	keepGoing := fs.Bool("keep-going", false, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	n := batchArgs[fs.Name()]
+	if len(args) <= n {
+		return result{}, errUsage
+	}
+	if init != nil {
+		if err := init(args); err != nil {
+			return result{}, err
+		}
+	}
+	fixed, inputs := args[:n], args[n:]
+
+	label := "argument"
+	if len(inputs) == 1 && inputs[0] == "-" {
+		data, err := io.ReadAll(e.stdin)
+		if err != nil {
+			return result{}, err
+		}
+		inputs = strings.Split(string(data), "\n")
+		label = "line"
+	} else if len(inputs) == 1 {
+		return fn(fixed, inputs[0])
+	}
+
+	var texts []string
+	entries := []batchJSON{}
+	var total, failed int
+	var first error
+	for i, str := range inputs {
+		str = strings.TrimSpace(str)
+		if str == "" && label == "line" {
+			continue
+		}
+		total++
+		res, err := fn(fixed, str)
+		entry := batchJSON{Input: str, OK: err == nil, Result: res.value}
+		if label == "line" {
+			entry.Line = i + 1
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		entries = append(entries, entry)
+		if err != nil {
+			err = fmt.Errorf("%s %d: %q: %w", label, i+1, str, err)
+			if !*keepGoing {
+				return result{text: joinLines(texts), value: entries}, err
+			}
+			_, _ = fmt.Fprintln(e.stderr, err.Error())
+			if first == nil {
+				first = err
+			}
+			failed++
+			continue
+		}
+		if !res.silent {
+			texts = append(texts, res.text)
+		}
+	}
+
+	res := result{text: joinLines(texts), value: entries}
+	if failed > 0 {
+		return res, fmt.Errorf("%d of %d inputs failed, first %w", failed, total, first)
+	}
+	return res, nil
+}
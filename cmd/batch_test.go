@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func Test_batch(t *testing.T) {
+	tests := []struct {
+		name       string
+		cmd        string
+		args       []string
+		stdin      string
+		want       string
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name: "single argument",
+			cmd:  "next",
+			args: []string{"minor", "1.2.3"},
+			want: "1.3.0",
+		},
+		{
+			name:    "single argument error is unchanged",
+			cmd:     "next",
+			args:    []string{"minor", "x"},
+			wantErr: "invalid semver string",
+		},
+		{
+			name: "multiple arguments",
+			cmd:  "strip",
+			args: []string{"all", "1.0.0-rc.1", "2.0.0+b"},
+			want: "1.0.0\n2.0.0\n",
+		},
+		{
+			name:  "stdin",
+			cmd:   "tags",
+			args:  []string{"-"},
+			stdin: "1.2.3\n\n2.0.0\n",
+			want:  "1.2.3 1.2 1\n2.0.0 2.0 2\n",
+		},
+		{
+			name:    "stop at first failure",
+			cmd:     "get",
+			args:    []string{"major", "-"},
+			stdin:   "1.2.3\nfoo\n2.0.0\nbar\n",
+			want:    "1\n",
+			wantErr: "line 2: \"foo\": invalid semver string",
+		},
+		{
+			name:       "keep going",
+			cmd:        "get",
+			args:       []string{"--keep-going", "major", "-"},
+			stdin:      "1.2.3\nfoo\n2.0.0\nbar\n",
+			want:       "1\n2\n",
+			wantStderr: "line 2: \"foo\": invalid semver string\nline 4: \"bar\": invalid semver string\n",
+			wantErr:    "2 of 4 inputs failed, first line 2: \"foo\": invalid semver string",
+		},
+		{
+			name:       "keep going with arguments",
+			cmd:        "valid",
+			args:       []string{"1.0.0", "1.0", "--keep-going"},
+			wantStderr: "argument 2: \"1.0\": invalid semver string\n",
+			wantErr:    "1 of 2 inputs failed",
+		},
+		{
+			name: "fixed arguments",
+			cmd:  "set",
+			args: []string{"pre", "rc.1", "1.0.0", "2.0.0"},
+			want: "1.0.0-rc.1\n2.0.0-rc.1\n",
+		},
+		{
+			name:    "missing input",
+			cmd:     "set",
+			args:    []string{"pre", "rc.1"},
+			wantErr: "invalid usage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, _ := lookup(tt.cmd)
+			stderr := &bytes.Buffer{}
+			got, err := cmd.run(tt.cmd, tt.args, env{stdin: strings.NewReader(tt.stdin), stderr: stderr})
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("batch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.text != tt.want {
+				t.Errorf("batch() got = %q, want %q", got.text, tt.want)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("batch() stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+			if err != nil && !errors.Is(err, semver.ErrInvalid) && !errors.Is(err, errUsage) {
+				t.Errorf("batch() error = %v, should wrap the failure", err)
+			}
+		})
+	}
+}
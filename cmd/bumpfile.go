@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/manifest"
+)
+
+// bumpFile bumps the version declared in files, to the next major, minor or patch
+// version of each file or to an explicit version. No file is written if any fails to load.
+func bumpFile(args []string) (result, error) {
+	fs := newFlagSet("bump-file")
+	dryRun := fs.Bool("dry-run", false, "")
+	key := fs.String("key", "", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) < 2 {
+		return result{}, errUsage
+	}
+
+	var files []*manifest.File
+	for _, path := range args[1:] {
+		f, err := manifest.Read(path, *key)
+		if err != nil {
+			return result{}, err
+		}
+		files = append(files, f)
+	}
+
+	var lines []string
+	bumps := []bumpJSON{}
+	for _, f := range files {
+		var ver semver.Version
+		switch strings.ToLower(args[0]) {
+		case "major":
+			ver = f.Version.NextMajor()
+		case "minor":
+			ver = f.Version.NextMinor()
+		case "patch":
+			ver = f.Version.NextPatch()
+		default:
+			ver, err = semver.Parse(args[0])
+			if err != nil {
+				return result{}, err
+			}
+		}
+
+		diff := f.Diff(ver)
+		if *dryRun {
+			lines = append(lines, strings.TrimSuffix(diff, "\n"))
+		} else {
+			if err := f.Write(ver); err != nil {
+				return result{text: joinLines(lines), value: bumps}, err
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s -> %s", f.Path, f.Version.String(), ver.String()))
+		}
+		bumps = append(bumps, bumpJSON{Path: f.Path, From: newVersionJSON(f.Version), To: newVersionJSON(ver), Diff: diff})
+	}
+
+	return result{text: joinLines(lines), value: bumps}, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_bumpFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	pkg := write("package.json", "{\n  \"version\": \"1.2.3\"\n}\n")
+	chart := write("Chart.yaml", "version: 0.1.0 # chart\nappVersion: 1.2.3\n")
+	src := write("version.go", "package main\n\nconst (\n\tname       = \"app\"   // name\n\tappVersion = \"1.2.3\" // version\n)\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name: "dry run",
+			args: []string{"minor", pkg, "--dry-run"},
+			want: "--- a/" + pkg + "\n+++ b/" + pkg + "\n@@ -2 +2 @@\n-  \"version\": \"1.2.3\"\n+  \"version\": \"1.3.0\"\n",
+			files: map[string]string{
+				pkg: "{\n  \"version\": \"1.2.3\"\n}\n",
+			},
+		},
+		{
+			name: "bump each file",
+			args: []string{"patch", pkg, chart},
+			want: pkg + ": 1.2.3 -> 1.2.4\n" + chart + ": 0.1.0 -> 0.1.1\n",
+			files: map[string]string{
+				pkg:   "{\n  \"version\": \"1.2.4\"\n}\n",
+				chart: "version: 0.1.1 # chart\nappVersion: 1.2.3\n",
+			},
+		},
+		{
+			name: "explicit version and key",
+			args: []string{"--key", "appVersion", "2.0.0-rc.1", chart},
+			want: chart + ": 1.2.3 -> 2.0.0-rc.1\n",
+			files: map[string]string{
+				chart: "version: 0.1.1 # chart\nappVersion: 2.0.0-rc.1\n",
+			},
+		},
+		{
+			name: "go identifier",
+			args: []string{"--key", "appVersion", "1.2.10", src},
+			want: src + ": 1.2.3 -> 1.2.10\n",
+			files: map[string]string{
+				src: "package main\n\nconst (\n\tname       = \"app\"    // name\n\tappVersion = \"1.2.10\" // version\n)\n",
+			},
+		},
+		{
+			name:    "nothing written if a file fails",
+			args:    []string{"major", pkg, filepath.Join(dir, "Makefile")},
+			wantErr: true,
+			files: map[string]string{
+				pkg: "{\n  \"version\": \"1.2.4\"\n}\n",
+			},
+		},
+		{
+			name:    "invalid version",
+			args:    []string{"1.2", pkg},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			args:    []string{"major"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bumpFile(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bumpFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("bumpFile() got = %q, want %q", got.text, tt.want)
+			}
+			for path, want := range tt.files {
+				data, _ := os.ReadFile(path)
+				if string(data) != want {
+					t.Errorf("bumpFile() %s = %q, want %q", path, data, want)
+				}
+			}
+		})
+	}
+}
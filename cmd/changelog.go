@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/changelog"
+	"github.com/nothub/semver/conventional"
+	"github.com/nothub/semver/git"
+)
+
+var errNoVersion = errors.New("range end is no version tag, use --version")
+
+var errLint = errors.New("changelog has problems")
+
+// changelogSection renders the changelog section of a git revision range
+// and optionally inserts it into a changelog file.
+func changelogSection(args []string) (result, error) {
+	fs := newFlagSet("changelog")
+	repo := fs.String("repo", ".", "")
+	version := fs.String("version", "", "")
+	date := fs.String("date", "", "")
+	file := fs.String("file", "", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) != 1 {
+		return result{}, errUsage
+	}
+	from, to, ok := strings.Cut(args[0], "..")
+	if !ok || from == "" {
+		return result{}, errUsage
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	var ver semver.Version
+	if *version != "" {
+		ver, err = semver.Parse(*version)
+	} else {
+		var tv semver.TaggedVersion
+		tv, err = semver.ParseTagged(to)
+		if err != nil {
+			err = errNoVersion
+		}
+		ver = tv.Version
+	}
+	if err != nil {
+		return result{}, err
+	}
+
+	r, err := git.Open(*repo)
+	if err != nil {
+		return result{}, err
+	}
+	log, err := r.Log(from, to)
+	if err != nil {
+		return result{}, err
+	}
+
+	var day time.Time
+	if *date != "" {
+		day, err = time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return result{}, err
+		}
+	} else {
+		head, err := r.Commit(to)
+		if err != nil {
+			return result{}, err
+		}
+		day = head.Time
+	}
+
+	var commits []conventional.Commit
+	for i := len(log) - 1; i >= 0; i-- {
+		c, err := conventional.Parse(log[i].Message)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, c)
+	}
+	section := changelog.FromCommits(ver, day, commits)
+
+	if *file != "" {
+		if err := insertSection(*file, section); err != nil {
+			return result{}, err
+		}
+	}
+
+	return textResult(section.Markdown()), nil
+}
+
+// changelogLint reports problems of a changelog file, all problems are returned as one error.
+func changelogLint(args []string) (result, error) {
+	fs := newFlagSet("changelog lint")
+	withGit := fs.Bool("git", false, "")
+	repo := fs.String("repo", ".", "")
+	prefix := fs.String("prefix", "v", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) > 1 {
+		return result{}, errUsage
+	}
+	path := "CHANGELOG.md"
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	doc, err := os.ReadFile(path)
+	if err != nil {
+		return result{}, err
+	}
+
+	var tags []semver.Version
+	if *withGit {
+		r, err := git.Open(*repo)
+		if err != nil {
+			return result{}, err
+		}
+		tags, err = r.Versions(*prefix, false)
+		if err != nil {
+			return result{}, err
+		}
+		if tags == nil {
+			tags = []semver.Version{}
+		}
+	}
+
+	problems := changelog.Lint(changelog.Parse(string(doc)), tags)
+	if len(problems) > 0 {
+		var strs []string
+		for _, p := range problems {
+			strs = append(strs, path+": "+p.String())
+		}
+		return result{value: strs}, fmt.Errorf("%w:\n%s", errLint, strings.Join(strs, "\n"))
+	}
+
+	return result{value: []string{}}, nil
+}
+
+func insertSection(path string, section changelog.Section) error {
+	doc, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	out, err := changelog.Insert(string(doc), section)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(out), 0o644)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test_changelogSection(t *testing.T) {
+	dir := gitRepo(t,
+		[]string{"commit", "-q", "--allow-empty", "-m", "feat: one"},
+		[]string{"tag", "v1.0.0"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "fix(parser): two"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "feat: three"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "chore: four"},
+		[]string{"tag", "v1.1.0"},
+	)
+	file := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(file, []byte("# Changelog\n\n## [1.0.0] - 2024-01-01\n\n- initial\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "tag range",
+			args: []string{"--repo", dir, "--date", "2024-02-01", "v1.0.0..v1.1.0"},
+			want: "## [1.1.0] - 2024-02-01\n\n### Added\n\n- three\n\n### Fixed\n\n- **parser:** two\n",
+		},
+		{
+			name: "explicit version",
+			args: []string{"--repo", dir, "--date", "2024-02-01", "--version", "1.0.1", "v1.0.0..HEAD~1"},
+			want: "## [1.0.1] - 2024-02-01\n\n### Added\n\n- three\n\n### Fixed\n\n- **parser:** two\n",
+		},
+		{
+			name: "insert into file",
+			args: []string{"--repo", dir, "--date", "2024-02-01", "--file", file, "v1.0.0..v1.1.0"},
+			want: "## [1.1.0] - 2024-02-01\n\n### Added\n\n- three\n\n### Fixed\n\n- **parser:** two\n",
+		},
+		{
+			name:    "range end is no version",
+			args:    []string{"--repo", dir, "v1.0.0..HEAD"},
+			wantErr: true,
+		},
+		{
+			name:    "no range",
+			args:    []string{"--repo", dir, "v1.0.0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := changelogSection(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("changelogSection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("changelogSection() got = %q, want %q", got.text, tt.want)
+			}
+		})
+	}
+
+	doc, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Changelog\n\n## [1.1.0] - 2024-02-01\n\n### Added\n\n- three\n\n### Fixed\n\n- **parser:** two\n\n## [1.0.0] - 2024-01-01\n\n- initial\n"
+	if string(doc) != want {
+		t.Errorf("unexpected changelog file:\nexpected = %q\nactual   = %q", want, string(doc))
+	}
+}
+
+func Test_changelogLint(t *testing.T) {
+	dir := gitRepo(t,
+		[]string{"commit", "-q", "--allow-empty", "-m", "one"},
+		[]string{"tag", "v1.0.0"},
+		[]string{"tag", "v1.1.0"},
+	)
+	write := func(doc string) string {
+		path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+		if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	clean := write("# Changelog\n\n## [Unreleased]\n\n## [1.1.0]\n\n## [1.0.0]\n")
+	broken := write("# Changelog\n\n## [1.0.0]\n\n## [1.1.0]\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		wantErr  string
+		wantCode int
+	}{
+		{
+			name: "clean",
+			args: []string{clean},
+			want: []string{},
+		},
+		{
+			name: "clean with git tags",
+			args: []string{"--git", "--repo", dir, clean},
+			want: []string{},
+		},
+		{
+			name:     "broken",
+			args:     []string{broken},
+			want:     []string{broken + ": line 5: version 1.1.0 is not older than 1.0.0 on line 3", broken + ": missing Unreleased section"},
+			wantErr:  "changelog has problems:\n" + broken + ": line 5: version 1.1.0 is not older than 1.0.0 on line 3\n" + broken + ": missing Unreleased section",
+			wantCode: exitFalse,
+		},
+		{
+			name:     "missing file",
+			args:     []string{filepath.Join(t.TempDir(), "missing.md")},
+			wantErr:  "open",
+			wantCode: exitFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := changelogLint(tt.args)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("changelogLint() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("changelogLint() error = %v, want %v", err, tt.wantErr)
+			}
+			if code := exitCode(err); code != tt.wantCode {
+				t.Errorf("changelogLint() exit code = %v, want %v", code, tt.wantCode)
+			}
+			if tt.want != nil && !slices.Equal(got.value.([]string), tt.want) {
+				t.Errorf("changelogLint() got = %v, want %v", got.value, tt.want)
+			}
+		})
+	}
+}
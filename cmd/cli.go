@@ -0,0 +1,587 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/manifest"
+)
+
+// Exit codes of the cli.
+const (
+	exitOK      = 0
+	exitFalse   = 1
+	exitUsage   = 2
+	exitInvalid = 3
+	exitFailure = 4
+)
+
+var errUsage = errors.New("invalid usage")
+
+// errFalse signals a predicate that does not hold.
+var errFalse = errors.New("predicate is false")
+
+// version is the version of the cli, set with -ldflags "-X main.version=..."
+// or read from the build info.
+var version = ""
+
+// env holds the standard streams of an invocation.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// option is a global option of the cli.
+type option struct {
+	short string
+	long  string
+	// synopsis of the value, empty for boolean options
+	value string
+	desc  string
+}
+
+var options = []option{
+	{short: "h", long: "help", desc: "Show help, use \"semver <command> --help\" for the help of a command"},
+	{short: "o", long: "output", value: "(text|json)", desc: "Select the output format, see README.md for the JSON schema"},
+	{long: "version", desc: "Print the version of semver"},
+}
+
+// synopsis returns the option as shown in the usage, e.g. "-o, --output (text|json)".
+func (opt *option) synopsis() string {
+	str := "--" + opt.long
+	if opt.short != "" {
+		str = "-" + opt.short + ", " + str
+	}
+	if opt.value != "" {
+		str += " " + opt.value
+	}
+	return str
+}
+
+// command is a subcommand of the cli.
+type command struct {
+	// names the command is dispatched on, e.g. gt, ge, lt, le and eq
+	names []string
+	desc  string
+	// synopses of the command, one per form
+	usage []string
+	run   func(name string, args []string, e env) (result, error)
+}
+
+var commands = []command{
+	{
+		names: []string{"next"},
+		desc:  "Bump to the next version, auto reads conventional commit messages from stdin without --git",
+		usage: []string{
+			"next (major|minor|patch) [--keep-going] (<version>...|-)",
+			"next auto [--git <range>] [--repo <path>] [-z|--null] <version>",
+		},
+		run: func(name string, args []string, e env) (result, error) {
+			if len(args) > 0 && strings.ToLower(args[0]) == "auto" {
+				return nextAuto(args[1:], e.stdin, e.stderr)
+			}
+			return batch(newFlagSet(name), args, e, nil, func(fixed []string, str string) (result, error) {
+				return next(fixed[0], str)
+			})
+		},
+	},
+	{
+		names: []string{"strip"},
+		desc:  "Remove pre-release or build metadata",
+		usage: []string{"strip (all|pre|build) [--keep-going] (<version>...|-)"},
+		run: func(name string, args []string, e env) (result, error) {
+			return batch(newFlagSet(name), args, e, nil, func(fixed []string, str string) (result, error) {
+				return strip(fixed[0], str)
+			})
+		},
+	},
+	{
+		names: []string{"get"},
+		desc:  "Print a single field of a version",
+		usage: []string{"get (major|minor|patch|pre|build) [--keep-going] (<version>...|-)"},
+		run: func(name string, args []string, e env) (result, error) {
+			return batch(newFlagSet(name), args, e, nil, func(fixed []string, str string) (result, error) {
+				return get(fixed[0], str)
+			})
+		},
+	},
+	{
+		names: []string{"set"},
+		desc:  "Replace a single field of a version",
+		usage: []string{"set (major|minor|patch|pre|build) <value> [--keep-going] (<version>...|-)"},
+		run: func(name string, args []string, e env) (result, error) {
+			return batch(newFlagSet(name), args, e, nil, func(fixed []string, str string) (result, error) {
+				return set(fixed[0], fixed[1], str)
+			})
+		},
+	},
+	{
+		names: []string{"valid"},
+		desc:  "Check input for conformity",
+		usage: []string{"valid [--keep-going] (<version>...|-)"},
+		run: func(name string, args []string, e env) (result, error) {
+			return batch(newFlagSet(name), args, e, nil, func(fixed []string, str string) (result, error) {
+				return valid(str)
+			})
+		},
+	},
+	{
+		names: []string{"compare"},
+		desc:  "Compare two versions, prints -1, 0 or 1",
+		usage: []string{"compare [--build] <version> <version>"},
+		run: func(name string, args []string, e env) (result, error) {
+			return compare(args)
+		},
+	},
+	{
+		names: []string{"gt", "ge", "lt", "le", "eq"},
+		desc:  "Check the order of two versions, exits with 0 if true and 1 if false",
+		usage: []string{"(gt|ge|lt|le|eq) [--build] <version> <version>"},
+		run: func(name string, args []string, e env) (result, error) {
+			ok, err := predicate(name, args)
+			if err != nil {
+				return result{}, err
+			}
+			if !ok {
+				return result{}, errFalse
+			}
+			return result{value: true}, nil
+		},
+	},
+	{
+		names: []string{"satisfies"},
+		desc:  "Check a version against a constraint, exits with 0 if satisfied and 1 if not",
+		usage: []string{"satisfies <constraint> <version>"},
+		run: func(name string, args []string, e env) (result, error) {
+			args, err := positionals(name, args, 2)
+			if err != nil {
+				return result{}, err
+			}
+			ok, err := satisfies(args[0], args[1])
+			if err != nil {
+				return result{}, err
+			}
+			if !ok {
+				return result{}, errFalse
+			}
+			return result{value: true}, nil
+		},
+	},
+	{
+		names: []string{"filter", "max-satisfying", "min-satisfying"},
+		desc:  "Match versions from arguments or stdin against a constraint",
+		usage: []string{"(filter|max-satisfying|min-satisfying) <constraint> [<version>...]"},
+		run: func(name string, args []string, e env) (result, error) {
+			args, err := parseFlags(newFlagSet(name), args)
+			if err != nil {
+				return result{}, err
+			}
+			return filterVersions(name, args, e.stdin)
+		},
+	},
+	{
+		names: []string{"sort"},
+		desc:  "Sort versions from arguments or stdin in ascending order",
+		usage: []string{"sort [--reverse] [--unique] [--releases-only] [--skip-invalid] [-z|--null] [<version>...]"},
+		run: func(name string, args []string, e env) (result, error) {
+			return sortVersions(args, e.stdin, e.stderr)
+		},
+	},
+	{
+		names: []string{"tags"},
+		desc:  "Expand to container tags, floating tags are omitted if a published version of their line is newer",
+		usage: []string{"tags [--prefix <prefix>] [--suffix|--variant <variant>] [--latest] [--channel <channel>] [--build] [--pre-release <policy>] [--no-major-for-zero] [--published <version>,...] [--published-from <file>] [--separator <separator>] [--format <template>] [--keep-going] (<version>...|-)"},
+		run: func(name string, args []string, e env) (result, error) {
+			fs := newFlagSet(name)
+			var opts tagOptions
+			fs.StringVar(&opts.plan.Prefix, "prefix", "", "")
+			fs.StringVar(&opts.plan.Variant, "suffix", "", "")
+			fs.StringVar(&opts.plan.Variant, "variant", "", "")
+			latest := fs.Bool("latest", false, "")
+			fs.StringVar(&opts.plan.Channel, "channel", "", "")
+			fs.BoolVar(&opts.plan.Build, "build", false, "")
+			fs.Func("pre-release", "", func(str string) error {
+				opts.plan.PreRelease = semver.PreReleasePolicy(str)
+				if !slices.Contains(semver.PreReleasePolicies, opts.plan.PreRelease) {
+					return fmt.Errorf("unknown policy %q", str)
+				}
+				return nil
+			})
+			fs.BoolVar(&opts.plan.NoMajorForZero, "no-major-for-zero", false, "")
+			var published []string
+			fs.Func("published", "", func(str string) error {
+				published = append(published, str)
+				return nil
+			})
+			from := fs.String("published-from", "", "")
+			sep := fs.String("separator", " ", "")
+			format := fs.String("format", "", "")
+			init := func(args []string) (err error) {
+				if *from == "-" && slices.Contains(args, "-") {
+					return errUsage
+				}
+				if *latest {
+					if opts.plan.Channel != "" && opts.plan.Channel != "latest" {
+						return fmt.Errorf("%w: --latest conflicts with --channel %s", errUsage, opts.plan.Channel)
+					}
+					opts.plan.Channel = "latest"
+				}
+				opts.separator = separatorReplacer.Replace(*sep)
+				if *format != "" {
+					if opts.format, err = parseFormat(*format); err != nil {
+						return err
+					}
+				}
+				opts.plan.Published, err = readPublished(published, *from, e.stdin)
+				return err
+			}
+			return batch(fs, args, e, init, func(fixed []string, str string) (result, error) {
+				return tags(str, opts)
+			})
+		},
+	},
+	{
+		names: []string{"prune"},
+		desc:  "Plan which container tags to keep and which to delete",
+		usage: []string{"prune [--majors <n>] [--minors <n>] [--pre-releases <n>] [--prefix <prefix>] [--variant <variant>] [--delete] [<tag>...]"},
+		run: func(name string, args []string, e env) (result, error) {
+			return prune(args, e.stdin)
+		},
+	},
+	{
+		names: []string{"backport"},
+		desc:  "List the maintained release branches affected by a bug and their next patch version",
+		usage: []string{"backport [--majors <n>] [--minors <n>] [--branch <template>] <introduced> [<version>...]"},
+		run: func(name string, args []string, e env) (result, error) {
+			return backport(args, e.stdin)
+		},
+	},
+	{
+		names: []string{"support-status"},
+		desc:  "Report if a version is supported, deprecated or reached its end of life and the version to upgrade to",
+		usage: []string{"support-status [--majors <n>] [--minors <n>] [--eol <file>] [--date <yyyy-mm-dd>] <version> [<version>...]"},
+		run: func(name string, args []string, e env) (result, error) {
+			return supportStatus(args, e.stdin)
+		},
+	},
+	{
+		names: []string{"describe"},
+		desc:  "Convert git describe output to a development version",
+		usage: []string{"describe [--template <template>] <describe-output>"},
+		run: func(name string, args []string, e env) (result, error) {
+			return describe(args)
+		},
+	},
+	{
+		names: []string{"changelog"},
+		desc:  "Render the changelog section of a git revision range or lint a changelog file",
+		usage: []string{
+			"changelog [--repo <path>] [--version <version>] [--date <yyyy-mm-dd>] [--file <changelog>] <from>..<to>",
+			"changelog lint [--git] [--repo <path>] [--prefix <prefix>] [<changelog>]",
+		},
+		run: func(name string, args []string, e env) (result, error) {
+			if len(args) > 0 && strings.ToLower(args[0]) == "lint" {
+				return changelogLint(args[1:])
+			}
+			return changelogSection(args)
+		},
+	},
+	{
+		names: []string{"bump-file"},
+		desc:  "Bump the version declared in VERSION, package.json, Cargo.toml, pyproject.toml, Chart.yaml or Go files",
+		usage: []string{"bump-file (major|minor|patch|<version>) [--dry-run] [--key <key>] <file>..."},
+		run: func(name string, args []string, e env) (result, error) {
+			return bumpFile(args)
+		},
+	},
+	{
+		names: []string{"check-consistency"},
+		desc:  "Check that the versions of files and the latest git tag match, exits with 1 if not",
+		usage: []string{"check-consistency [--build] [--repo <path>] [--prefix <prefix>] (<file>[:<key>]|git)..."},
+		run: func(name string, args []string, e env) (result, error) {
+			return checkConsistency(args)
+		},
+	},
+	{
+		names: []string{"git"},
+		desc:  "Read versions from the tags of a local git repository",
+		usage: []string{"git (latest|list) [--repo <path>] [--prefix <prefix>] [--reachable]"},
+		run: func(name string, args []string, e env) (result, error) {
+			return gitTags(args)
+		},
+	},
+}
+
+// lookup returns the command dispatched on name.
+func lookup(name string) (command, bool) {
+	for _, cmd := range commands {
+		for _, n := range cmd.names {
+			if n == name {
+				return cmd, true
+			}
+		}
+	}
+	return command{}, false
+}
+
+// run executes the cli and returns the exit code.
+func run(args []string, e env) int {
+	fs := newFlagSet("semver")
+	output := fs.String("output", "text", "")
+	fs.StringVar(output, "o", "text", "")
+	showVersion := fs.Bool("version", false, "")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprint(e.stdout, usage())
+			return exitOK
+		}
+		return report(e, "text", "", nil, result{}, fmt.Errorf("%w: %s", errUsage, err))
+	}
+	format := strings.ToLower(*output)
+	if format != "text" && format != "json" {
+		return report(e, "text", "", nil, result{}, fmt.Errorf("%w: unknown output format %q", errUsage, *output))
+	}
+	if *showVersion {
+		return report(e, format, "version", nil, textResult(cliVersion()), nil)
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		return report(e, format, "", nil, result{}, errUsage)
+	}
+	name := strings.ToLower(args[0])
+	args = args[1:]
+
+	cmd, ok := lookup(name)
+	if !ok {
+		return report(e, format, name, args, result{}, fmt.Errorf("%w: unknown command %q", errUsage, name))
+	}
+	if wantsHelp(args) {
+		_, _ = fmt.Fprint(e.stdout, cmd.help())
+		return exitOK
+	}
+
+	res, err := cmd.run(name, args, e)
+	return report(e, format, name, args, res, err)
+}
+
+// report prints the outcome of a command in the selected format and returns the exit code.
+func report(e env, format string, name string, args []string, res result, err error) int {
+	code := exitCode(err)
+
+	if format == "json" {
+		if err := writeDocument(e.stdout, newDocument(name, args, res, err)); err != nil {
+			_, _ = fmt.Fprintln(e.stderr, err.Error())
+			return exitFailure
+		}
+	} else if res.text != "" {
+		_, _ = fmt.Fprint(e.stdout, res.text)
+	}
+
+	switch {
+	case code == exitUsage:
+		if format != "json" {
+			_, _ = fmt.Fprintln(e.stderr, err.Error())
+		}
+		if cmd, ok := lookup(name); ok {
+			_, _ = fmt.Fprint(e.stderr, cmd.help())
+		} else {
+			_, _ = fmt.Fprint(e.stderr, usage())
+		}
+	case err != nil && !errors.Is(err, errFalse) && format != "json":
+		_, _ = fmt.Fprintln(e.stderr, err.Error())
+	}
+
+	return code
+}
+
+// exitCode maps the error of a command to an exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errFalse), errors.Is(err, errUnsatisfied), errors.Is(err, errInconsistent), errors.Is(err, errLint):
+		return exitFalse
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, semver.ErrInvalid),
+		errors.Is(err, strconv.ErrRange),
+		errors.Is(err, strconv.ErrSyntax),
+		errors.Is(err, semver.ErrConstraint),
+		errors.Is(err, semver.ErrTemplate),
+		errors.Is(err, semver.ErrContainerTag),
+		errors.Is(err, semver.ErrEOLTable),
+		errors.Is(err, errNoVersion),
+		errors.Is(err, manifest.ErrNotFound),
+		errors.Is(err, manifest.ErrUnsupported):
+		return exitInvalid
+	default:
+		return exitFailure
+	}
+}
+
+// usage returns the help of the cli.
+func usage() string {
+	sb := strings.Builder{}
+	sb.WriteString(`semver - utilities for semantic versioning
+
+Usage: semver [opts...] <command> [args...]
+
+Options:
+`)
+	for _, opt := range options {
+		_, _ = fmt.Fprintf(&sb, "    %-26s%s\n", opt.synopsis(), opt.desc)
+	}
+	sb.WriteString("\nCommands:\n")
+	for i, cmd := range commands {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		for _, line := range strings.Split(cmd.help(), "\n") {
+			if line != "" {
+				sb.WriteString("    ")
+				sb.WriteString(line)
+				sb.WriteString("\n")
+			}
+		}
+	}
+	sb.WriteString(`
+Exit codes:
+    0 - Success
+    1 - A predicate is false, no version satisfies a constraint or versions are inconsistent
+    2 - Invalid usage
+    3 - Invalid input, like a malformed version or constraint
+    4 - Any other failure
+`)
+	return sb.String()
+}
+
+// help returns the help of a single command.
+func (cmd *command) help() string {
+	sb := strings.Builder{}
+	sb.WriteString(strings.Join(cmd.names, ", "))
+	sb.WriteString(" - ")
+	sb.WriteString(cmd.desc)
+	sb.WriteString("\n")
+	for _, line := range cmd.usage {
+		sb.WriteString("Usage: semver [opts...] ")
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func cliVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags parses flags placed before, between or after positional arguments
+// and returns the positional arguments. Arguments following "--" are never flags,
+// neither are arguments like -1 or -0.0.0 that are no registered flag.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for len(args) > 0 {
+		if negativeNumber(fs, args[0]) {
+			pos = append(pos, args[0])
+			args = args[1:]
+			continue
+		}
+		end := flagsEnd(fs, args)
+		if err := fs.Parse(args[:end]); err != nil {
+			return nil, fmt.Errorf("%w: %s", errUsage, err)
+		}
+		rest := fs.Args()
+		if n := end - len(rest); n > 0 && args[n-1] == "--" {
+			return append(pos, args[n:]...), nil
+		}
+		if len(rest) > 0 {
+			pos = append(pos, rest[0])
+			rest = rest[1:]
+		}
+		args = slices.Concat(rest, args[end:])
+	}
+	return pos, nil
+}
+
+// flagsEnd returns the index of the first negative number in args that is no flag value.
+func flagsEnd(fs *flag.FlagSet, args []string) int {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return len(args)
+		case negativeNumber(fs, args[i]):
+			return i
+		case wantsValue(fs, args[i]):
+			i++
+		}
+	}
+	return len(args)
+}
+
+// negativeNumber reports if arg starts with a dash followed by a digit and is no registered flag.
+func negativeNumber(fs *flag.FlagSet, arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' || arg[1] < '0' || arg[1] > '9' {
+		return false
+	}
+	name, _, _ := strings.Cut(arg[1:], "=")
+	return fs.Lookup(name) == nil
+}
+
+// wantsValue reports if arg is a registered flag followed by its value, like --majors 1.
+func wantsValue(fs *flag.FlagSet, arg string) bool {
+	name, ok := strings.CutPrefix(arg, "-")
+	if !ok || strings.Contains(name, "=") {
+		return false
+	}
+	f := fs.Lookup(strings.TrimPrefix(name, "-"))
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return !ok || !b.IsBoolFlag()
+}
+
+// positionals parses the arguments of a command without flags and checks their count.
+func positionals(name string, args []string, n int) ([]string, error) {
+	args, err := parseFlags(newFlagSet(name), args)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != n {
+		return nil, errUsage
+	}
+	return args, nil
+}
+
+// wantsHelp reports if the arguments of a command ask for its help.
+func wantsHelp(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-h", "-help", "--help":
+			return true
+		}
+	}
+	return false
+}
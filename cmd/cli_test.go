@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_run(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantOut    string
+		wantStderr string
+		wantCode   int
+	}{
+		{
+			name:     "next",
+			args:     []string{"next", "minor", "1.2.3"},
+			wantOut:  "1.3.0",
+			wantCode: exitOK,
+		},
+		{
+			name:     "command name is case insensitive",
+			args:     []string{"NEXT", "patch", "1.2.3"},
+			wantOut:  "1.2.4",
+			wantCode: exitOK,
+		},
+		{
+			name:     "flag after positionals",
+			args:     []string{"compare", "1.0.0+a", "1.0.0", "--build"},
+			wantOut:  "1",
+			wantCode: exitOK,
+		},
+		{
+			name:     "flag between positionals",
+			args:     []string{"sort", "2.0.0", "--reverse", "1.0.0", "3.0.0"},
+			wantOut:  "3.0.0\n2.0.0\n1.0.0",
+			wantCode: exitOK,
+		},
+		{
+			name:     "no flags after double dash",
+			args:     []string{"sort", "--", "--reverse"},
+			wantCode: exitInvalid,
+		},
+		{
+			name:       "support status date without eol table",
+			args:       []string{"support-status", "--date", "2025-01-01", "1.0.0"},
+			wantStderr: "--date requires --eol",
+			wantCode:   exitUsage,
+		},
+		{
+			name:     "version out of range",
+			args:     []string{"valid", "99999999999999999999.0.0"},
+			wantCode: exitInvalid,
+		},
+		{
+			name:     "field out of range",
+			args:     []string{"set", "major", "99999999999999999999", "1.2.3"},
+			wantCode: exitInvalid,
+		},
+		{
+			name:     "negative number is no flag",
+			args:     []string{"valid", "-0.0.0"},
+			wantCode: exitInvalid,
+		},
+		{
+			name:     "negative number between flags",
+			args:     []string{"prune", "--majors", "-1", "-1", "--delete", "1.0.1", "1.0.0"},
+			wantOut:  "1.0.0",
+			wantCode: exitOK,
+		},
+		{
+			name:     "stdin",
+			args:     []string{"max-satisfying", "^1"},
+			stdin:    "1.0.0\n1.5.0\n2.0.0\n",
+			wantOut:  "1.5.0",
+			wantCode: exitOK,
+		},
+		{
+			name:       "batch keep going",
+			args:       []string{"next", "patch", "--keep-going", "-"},
+			stdin:      "1.0.0\nfoo\n2.0.0\n",
+			wantOut:    "1.0.1\n2.0.1",
+			wantStderr: "line 2: \"foo\": invalid semver string\n1 of 3 inputs failed",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:     "batch json output",
+			args:     []string{"-o", "json", "tags", "1.0.0", "2.0.0"},
+			wantOut:  `{"command":"tags","args":["1.0.0","2.0.0"],"ok":true,"result":[{"input":"1.0.0","ok":true,"result":["1.0.0","1.0","1"]},{"input":"2.0.0","ok":true,"result":["2.0.0","2.0","2"]}]}`,
+			wantCode: exitOK,
+		},
+		{
+			name:       "batch json output with failures",
+			args:       []string{"-o", "json", "get", "major", "--keep-going", "-"},
+			stdin:      "1.0.0\n\nfoo\n2.0.0\n",
+			wantOut:    `{"command":"get","args":["major","--keep-going","-"],"ok":false,"result":[{"input":"1.0.0","ok":true,"result":"1","line":1},{"input":"foo","ok":false,"result":null,"error":"invalid semver string","line":3},{"input":"2.0.0","ok":true,"result":"2","line":4}],"error":"1 of 3 inputs failed, first line 3: \"foo\": invalid semver string"}`,
+			wantStderr: "line 3: \"foo\": invalid semver string\n",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:       "tags with build metadata exceeding the tag limit",
+			args:       []string{"tags", "--build", "1.0.0+" + strings.Repeat("a", 128)},
+			wantStderr: "invalid container tag",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:     "tags with variant and separator",
+			args:     []string{"tags", "--prefix", "v", "--variant", "alpine", "--latest", "--separator", `\n`, "1.2.3"},
+			wantOut:  "v1.2.3-alpine\nv1.2-alpine\nv1-alpine\nalpine",
+			wantCode: exitOK,
+		},
+		{
+			name:     "tags json with separator",
+			args:     []string{"-o", "json", "tags", "--suffix", "alpine", "--separator", ",", "1.2.3"},
+			wantOut:  `{"command":"tags","args":["--suffix","alpine","--separator",",","1.2.3"],"ok":true,"result":["1.2.3-alpine","1.2-alpine","1-alpine"]}`,
+			wantCode: exitOK,
+		},
+		{
+			name:     "tags with pre-release channels",
+			args:     []string{"tags", "--pre-release", "channel-floating", "--no-major-for-zero", "0.2.0-rc.1"},
+			wantOut:  "0.2.0-rc.1 0.2-rc rc",
+			wantCode: exitOK,
+		},
+		{
+			name:       "tags with unknown pre-release policy",
+			args:       []string{"tags", "--pre-release", "all", "1.2.3-rc.1"},
+			wantStderr: `unknown policy "all"`,
+			wantCode:   exitUsage,
+		},
+		{
+			name:       "tags with conflicting channels",
+			args:       []string{"tags", "--latest", "--channel", "stable", "1.2.3"},
+			wantStderr: "--latest conflicts with --channel stable",
+			wantCode:   exitUsage,
+		},
+		{
+			name:       "tags with invalid format",
+			args:       []string{"tags", "--format", "{{.Name", "1.2.3"},
+			wantStderr: "invalid template",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:     "tags with published versions from stdin",
+			args:     []string{"tags", "1.1.9", "--published-from", "-", "--latest"},
+			stdin:    "1.1.8\n1.2.0\n",
+			wantOut:  "1.1.9 1.1",
+			wantCode: exitOK,
+		},
+		{
+			name:       "tags with published versions and inputs from stdin",
+			args:       []string{"tags", "--published-from", "-", "-"},
+			wantStderr: "invalid usage",
+			wantCode:   exitUsage,
+		},
+		{
+			name:     "predicate true",
+			args:     []string{"gt", "2.0.0", "1.0.0"},
+			wantCode: exitOK,
+		},
+		{
+			name:     "predicate false",
+			args:     []string{"gt", "1.0.0", "2.0.0"},
+			wantCode: exitFalse,
+		},
+		{
+			name:       "unsatisfied",
+			args:       []string{"max-satisfying", "^3", "1.0.0"},
+			wantStderr: "no version satisfies the constraint",
+			wantCode:   exitFalse,
+		},
+		{
+			name:       "invalid version",
+			args:       []string{"strip", "all", "1.2"},
+			wantStderr: "invalid semver string",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:       "invalid constraint",
+			args:       []string{"satisfies", "foo", "1.0.0"},
+			wantStderr: "invalid constraint",
+			wantCode:   exitInvalid,
+		},
+		{
+			name:       "missing argument",
+			args:       []string{"next", "major"},
+			wantStderr: "Usage: semver [opts...] next (major|minor|patch) [--keep-going] (<version>...|-)",
+			wantCode:   exitUsage,
+		},
+		{
+			name:       "unknown flag",
+			args:       []string{"strip", "--bogus", "all", "1.2.3"},
+			wantStderr: "flag provided but not defined: -bogus",
+			wantCode:   exitUsage,
+		},
+		{
+			name:       "unknown command",
+			args:       []string{"bogus"},
+			wantStderr: "unknown command \"bogus\"",
+			wantCode:   exitUsage,
+		},
+		{
+			name:       "no command",
+			args:       []string{},
+			wantStderr: "Commands:",
+			wantCode:   exitUsage,
+		},
+		{
+			name:     "help",
+			args:     []string{"--help"},
+			wantOut:  "semver - utilities for semantic versioning",
+			wantCode: exitOK,
+		},
+		{
+			name:     "command help",
+			args:     []string{"strip", "--help"},
+			wantOut:  "strip - Remove pre-release or build metadata\nUsage: semver [opts...] strip (all|pre|build) [--keep-going] (<version>...|-)\n",
+			wantCode: exitOK,
+		},
+		{
+			name:     "command help after positionals",
+			args:     []string{"gt", "1.0.0", "-h"},
+			wantOut:  "gt, ge, lt, le, eq - ",
+			wantCode: exitOK,
+		},
+		{
+			name:     "version",
+			args:     []string{"--version"},
+			wantCode: exitOK,
+		},
+		{
+			name:     "json output",
+			args:     []string{"-o", "json", "valid", "1.2.3"},
+			wantOut:  `{"command":"valid","args":["1.2.3"],"ok":true,"result":{"version":"1.2.3",`,
+			wantCode: exitOK,
+		},
+		{
+			name:     "json output of errors",
+			args:     []string{"--output=json", "valid", "1.2"},
+			wantOut:  `{"command":"valid","args":["1.2"],"ok":false,"result":null,"error":"invalid semver string"}`,
+			wantCode: exitInvalid,
+		},
+		{
+			name:       "unknown output format",
+			args:       []string{"--output", "yaml", "valid", "1.2.3"},
+			wantStderr: "unknown output format \"yaml\"",
+			wantCode:   exitUsage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			code := run(tt.args, env{stdin: strings.NewReader(tt.stdin), stdout: stdout, stderr: stderr})
+			if code != tt.wantCode {
+				t.Errorf("run() code = %v, want %v, stderr = %q", code, tt.wantCode, stderr.String())
+			}
+			if !strings.HasPrefix(stdout.String(), tt.wantOut) {
+				t.Errorf("run() stdout = %q, want %q", stdout.String(), tt.wantOut)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run() stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_run_output(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantOut    string
+		wantStderr string
+	}{
+		{
+			name:    "single result",
+			args:    []string{"next", "patch", "1.0.0"},
+			wantOut: "1.0.1",
+		},
+		{
+			name:    "batch",
+			args:    []string{"next", "patch", "1.0.0", "2.0.0"},
+			wantOut: "1.0.1\n2.0.1\n",
+		},
+		{
+			name:       "batch keep going",
+			args:       []string{"next", "patch", "--keep-going", "-"},
+			stdin:      "1.0.0\nfoo\n2.0.0\n",
+			wantOut:    "1.0.1\n2.0.1\n",
+			wantStderr: "line 2: \"foo\": invalid semver string\n1 of 3 inputs failed, first line 2: \"foo\": invalid semver string\n",
+		},
+		{
+			name:    "batch with empty results",
+			args:    []string{"get", "pre", "1.0.0", "1.0.0-rc.1"},
+			wantOut: "\nrc.1\n",
+		},
+		{
+			name:    "batch valid",
+			args:    []string{"valid", "1.0.0", "2.0.0", "3.0.0"},
+			wantOut: "",
+		},
+		{
+			name:    "sort",
+			args:    []string{"sort", "2.0.0", "1.0.0"},
+			wantOut: "1.0.0\n2.0.0\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			run(tt.args, env{stdin: strings.NewReader(tt.stdin), stdout: stdout, stderr: stderr})
+			if stdout.String() != tt.wantOut {
+				t.Errorf("run() stdout = %q, want %q", stdout.String(), tt.wantOut)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("run() stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_parseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "flags first",
+			args: []string{"--repo", "x", "a", "b"},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "flags last",
+			args: []string{"a", "b", "--repo=x"},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "double dash",
+			args: []string{"--repo", "x", "a", "--", "--repo", "y"},
+			want: []string{"a", "--repo", "y"},
+		},
+		{
+			name: "stdin placeholder",
+			args: []string{"-", "--repo", "x"},
+			want: []string{"-"},
+		},
+		{
+			name: "negative numbers",
+			args: []string{"-1", "--repo", "x", "a", "-0.0.0"},
+			want: []string{"-1", "a", "-0.0.0"},
+		},
+		{
+			name: "negative number as flag value",
+			args: []string{"--repo", "x", "--level", "-1", "a"},
+			want: []string{"a"},
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"a", "--bogus"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("test")
+			repo := fs.String("repo", "", "")
+			fs.Int("level", 0, "")
+			got, err := parseFlags(fs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("parseFlags() got = %v, want %v", got, tt.want)
+			}
+			if *repo != "x" {
+				t.Errorf("parseFlags() repo = %v, want x", *repo)
+			}
+		})
+	}
+}
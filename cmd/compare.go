@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// compareArgs parses two versions and compares them, including build metadata if requested.
+func compareArgs(name string, args []string) (int, error) {
+	fs := newFlagSet(name)
+	build := fs.Bool("build", false, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(args) != 2 {
+		return 0, errUsage
+	}
+
+	a, err := semver.Parse(args[0])
+	if err != nil {
+		return 0, err
+	}
+	b, err := semver.Parse(args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	if *build {
+		return semver.CompareBuild(a, b), nil
+	}
+	return semver.Compare(a, b), nil
+}
+
+func compare(args []string) (result, error) {
+	n, err := compareArgs("compare", args)
+	if err != nil {
+		return result{}, err
+	}
+	return result{text: strconv.Itoa(n), value: n}, nil
+}
+
+// predicate reports if the comparison of two versions satisfies an operator.
+func predicate(op string, args []string) (bool, error) {
+	result, err := compareArgs(op, args)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(op) {
+	case "gt":
+		return result > 0, nil
+	case "ge":
+		return result >= 0, nil
+	case "lt":
+		return result < 0, nil
+	case "le":
+		return result <= 0, nil
+	case "eq":
+		return result == 0, nil
+	default:
+		return false, errUsage
+	}
+}
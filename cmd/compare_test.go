@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+func Test_compare(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "older",
+			args: []string{"1.0.0-rc.1", "1.0.0"},
+			want: "-1",
+		},
+		{
+			name: "newer",
+			args: []string{"1.0.0-rc.11", "1.0.0-rc.2"},
+			want: "1",
+		},
+		{
+			name: "same ignoring build",
+			args: []string{"1.0.0+2", "1.0.0+1"},
+			want: "0",
+		},
+		{
+			name: "newer including build",
+			args: []string{"--build", "1.0.0+2", "1.0.0+1"},
+			want: "1",
+		},
+		{
+			name:    "invalid version",
+			args:    []string{"1.0", "1.0.0"},
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			args:    []string{"1.0.0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compare(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compare() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("compare() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
+
+func Test_predicate(t *testing.T) {
+	tests := []struct {
+		op      string
+		args    []string
+		want    bool
+		wantErr bool
+	}{
+		{op: "gt", args: []string{"1.0.0", "1.0.0-rc.1"}, want: true},
+		{op: "gt", args: []string{"1.0.0", "1.0.0"}, want: false},
+		{op: "ge", args: []string{"1.0.0", "1.0.0+build"}, want: true},
+		{op: "lt", args: []string{"1.0.0-alpha", "1.0.0-alpha.1"}, want: true},
+		{op: "lt", args: []string{"2.0.0", "1.99.99"}, want: false},
+		{op: "le", args: []string{"1.0.0", "1.0.0"}, want: true},
+		{op: "eq", args: []string{"1.0.0+a", "1.0.0+b"}, want: true},
+		{op: "eq", args: []string{"--build", "1.0.0+a", "1.0.0+b"}, want: false},
+		{op: "eq", args: []string{"1.0.0", "01.0.0"}, wantErr: true},
+		{op: "bogus", args: []string{"1.0.0", "1.0.0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.op+" "+tt.args[0]+" "+tt.args[1], func(t *testing.T) {
+			got, err := predicate(tt.op, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("predicate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("predicate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// spec is the part of a command relevant for shell completion, parsed from its usage synopses.
+type spec struct {
+	names []string
+	desc  string
+	// choices of the first argument, e.g. major, minor and patch
+	modes []string
+	flags []flagSpec
+}
+
+type flagSpec struct {
+	// name including dashes, e.g. --repo or -z
+	name string
+	// flag takes a value
+	value bool
+}
+
+var wordRegex = regexp.MustCompile(`^[a-z]+$`)
+
+func init() {
+	// registered on init, the completion refers to the commands itself
+	commands = append(commands, command{
+		names: []string{"completion"},
+		desc:  "Print the shell completion script of bash, zsh or fish",
+		usage: []string{"completion (bash|zsh|fish)"},
+		run: func(name string, args []string, e env) (result, error) {
+			args, err := positionals(name, args, 1)
+			if err != nil {
+				return result{}, err
+			}
+			return completion(args[0])
+		},
+	})
+}
+
+// spec parses the usage synopses of a command.
+func (cmd *command) spec() spec {
+	s := spec{names: cmd.names, desc: cmd.desc}
+	for _, line := range cmd.usage {
+		tokens := strings.Fields(line)[1:]
+		if len(tokens) > 0 && !strings.HasPrefix(tokens[0], "[") {
+			for _, mode := range strings.Split(strings.Trim(tokens[0], "()"), "|") {
+				if wordRegex.MatchString(mode) && !slices.Contains(s.modes, mode) {
+					s.modes = append(s.modes, mode)
+				}
+			}
+		}
+		for i, token := range tokens {
+			if !strings.HasPrefix(token, "[-") {
+				continue
+			}
+			value := !strings.HasSuffix(token, "]") && i+1 < len(tokens) && strings.HasPrefix(tokens[i+1], "<")
+			for _, name := range strings.Split(strings.Trim(token, "[]"), "|") {
+				if !slices.ContainsFunc(s.flags, func(f flagSpec) bool { return f.name == name }) {
+					s.flags = append(s.flags, flagSpec{name: name, value: value})
+				}
+			}
+		}
+	}
+	s.flags = append(s.flags, flagSpec{name: "--help"})
+	return s
+}
+
+// flagNames returns the names of the flags of a spec.
+func (s *spec) flagNames() []string {
+	var names []string
+	for _, f := range s.flags {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+// names returns the flags of an option, e.g. -o and --output.
+func (opt *option) names() []string {
+	if opt.short == "" {
+		return []string{"--" + opt.long}
+	}
+	return []string{"-" + opt.short, "--" + opt.long}
+}
+
+// choices returns the values of an option, e.g. text and json.
+func (opt *option) choices() []string {
+	var choices []string
+	for _, choice := range strings.Split(strings.Trim(opt.value, "()"), "|") {
+		if wordRegex.MatchString(choice) {
+			choices = append(choices, choice)
+		}
+	}
+	return choices
+}
+
+// completion renders the completion script of a shell, one of bash, zsh or fish.
+func completion(shell string) (result, error) {
+	var specs []spec
+	for _, cmd := range commands {
+		specs = append(specs, cmd.spec())
+	}
+
+	switch strings.ToLower(shell) {
+	case "bash":
+		return textResult(bashCompletion(specs)), nil
+	case "zsh":
+		return textResult(zshCompletion(specs)), nil
+	case "fish":
+		return textResult(fishCompletion(specs)), nil
+	default:
+		return result{}, errUsage
+	}
+}
+
+func bashCompletion(specs []spec) string {
+	var globals, cmds []string
+	sb := strings.Builder{}
+	sb.WriteString(`# bash completion for semver, generated by "semver completion bash"
+_semver() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    local cmd="" i
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        case "${COMP_WORDS[i]}" in
+`)
+	for _, opt := range options {
+		globals = append(globals, opt.names()...)
+		if opt.value != "" {
+			_, _ = fmt.Fprintf(&sb, "            %s) ((i++)) ;;\n", strings.Join(opt.names(), "|"))
+		}
+	}
+	sb.WriteString(`            -*) ;;
+            *) cmd="${COMP_WORDS[i]}"; break ;;
+        esac
+    done
+
+    local modes="" flags=""
+    case "$cmd" in
+        "")
+            case "$prev" in
+`)
+	for _, opt := range options {
+		if choices := opt.choices(); choices != nil {
+			_, _ = fmt.Fprintf(&sb, "                %s) COMPREPLY=($(compgen -W %s -- \"$cur\")); return ;;\n", strings.Join(opt.names(), "|"), shellQuote(strings.Join(choices, " ")))
+		}
+	}
+	for _, s := range specs {
+		cmds = append(cmds, s.names...)
+	}
+	_, _ = fmt.Fprintf(&sb, `            esac
+            COMPREPLY=($(compgen -W %s -- "$cur"))
+            return
+            ;;
+`, shellQuote(strings.Join(append(globals, cmds...), " ")))
+	for _, s := range specs {
+		_, _ = fmt.Fprintf(&sb, "        %s)\n", strings.Join(s.names, "|"))
+		if len(s.modes) > 0 {
+			_, _ = fmt.Fprintf(&sb, "            modes=%s\n", shellQuote(strings.Join(s.modes, " ")))
+		}
+		_, _ = fmt.Fprintf(&sb, "            flags=%s\n", shellQuote(strings.Join(s.flagNames(), " ")))
+		sb.WriteString("            ;;\n")
+	}
+	sb.WriteString(`    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    elif ((COMP_CWORD == i + 1)) && [[ -n "$modes" ]]; then
+        COMPREPLY=($(compgen -W "$modes" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -f -- "$cur"))
+    fi
+}
+
+complete -F _semver semver
+`)
+	return sb.String()
+}
+
+func zshCompletion(specs []spec) string {
+	var globals []string
+	sb := strings.Builder{}
+	sb.WriteString(`#compdef semver
+# zsh completion for semver, generated by "semver completion zsh"
+_semver() {
+    local i cmd=""
+    for ((i = 2; i < CURRENT; i++)); do
+        case ${words[i]} in
+`)
+	for _, opt := range options {
+		globals = append(globals, opt.names()...)
+		if opt.value != "" {
+			_, _ = fmt.Fprintf(&sb, "            %s) ((i++)) ;;\n", strings.Join(opt.names(), "|"))
+		}
+	}
+	sb.WriteString(`            -*) ;;
+            *) cmd=${words[i]}; break ;;
+        esac
+    done
+
+    local -a modes flags commands
+    case $cmd in
+        "")
+            case ${words[CURRENT-1]} in
+`)
+	for _, opt := range options {
+		if choices := opt.choices(); choices != nil {
+			_, _ = fmt.Fprintf(&sb, "                %s) compadd -- %s; return ;;\n", strings.Join(opt.names(), "|"), strings.Join(choices, " "))
+		}
+	}
+	_, _ = fmt.Fprintf(&sb, `            esac
+            if [[ $PREFIX == -* ]]; then
+                compadd -- %s
+                return
+            fi
+            commands=(
+`, strings.Join(globals, " "))
+	for _, s := range specs {
+		for _, name := range s.names {
+			_, _ = fmt.Fprintf(&sb, "                %s\n", shellQuote(name+":"+s.desc))
+		}
+	}
+	sb.WriteString(`            )
+            _describe command commands
+            return
+            ;;
+`)
+	for _, s := range specs {
+		_, _ = fmt.Fprintf(&sb, "        %s)\n", strings.Join(s.names, "|"))
+		if len(s.modes) > 0 {
+			_, _ = fmt.Fprintf(&sb, "            modes=(%s)\n", strings.Join(s.modes, " "))
+		}
+		_, _ = fmt.Fprintf(&sb, "            flags=(%s)\n", strings.Join(s.flagNames(), " "))
+		sb.WriteString("            ;;\n")
+	}
+	sb.WriteString(`    esac
+
+    if [[ $PREFIX == -* ]]; then
+        compadd -- $flags
+    elif ((CURRENT == i + 1 && ${#modes} > 0)); then
+        compadd -- $modes
+    else
+        _files
+    fi
+}
+
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+    _semver "$@"
+else
+    compdef _semver semver
+fi
+`)
+	return sb.String()
+}
+
+func fishCompletion(specs []spec) string {
+	sb := strings.Builder{}
+	sb.WriteString(`# fish completion for semver, generated by "semver completion fish"
+complete -c semver -f
+`)
+	for _, opt := range options {
+		sb.WriteString("complete -c semver -n __fish_use_subcommand")
+		if opt.short != "" {
+			sb.WriteString(" -s " + opt.short)
+		}
+		sb.WriteString(" -l " + opt.long)
+		if choices := opt.choices(); choices != nil {
+			sb.WriteString(" -x -a " + shellQuote(strings.Join(choices, " ")))
+		}
+		sb.WriteString(" -d " + shellQuote(opt.desc) + "\n")
+	}
+	for _, s := range specs {
+		for _, name := range s.names {
+			_, _ = fmt.Fprintf(&sb, "complete -c semver -n __fish_use_subcommand -a %s -d %s\n", name, shellQuote(s.desc))
+		}
+	}
+	for _, s := range specs {
+		cond := "__fish_seen_subcommand_from " + strings.Join(s.names, " ")
+		if len(s.modes) > 0 {
+			_, _ = fmt.Fprintf(&sb, "complete -c semver -n %s -a %s\n",
+				shellQuote(cond+"; and not __fish_seen_subcommand_from "+strings.Join(s.modes, " ")),
+				shellQuote(strings.Join(s.modes, " ")))
+		}
+		for _, f := range s.flags {
+			sb.WriteString("complete -c semver -n " + shellQuote(cond))
+			if strings.HasPrefix(f.name, "--") {
+				sb.WriteString(" -l " + strings.TrimPrefix(f.name, "--"))
+			} else {
+				sb.WriteString(" -s " + strings.TrimPrefix(f.name, "-"))
+			}
+			if f.value {
+				sb.WriteString(" -r -F")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// shellQuote quotes a string with single quotes, valid for bash, zsh and fish.
+func shellQuote(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", `'\''`) + "'"
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_command_spec(t *testing.T) {
+	cmd, _ := lookup("next")
+	s := cmd.spec()
+	if got := strings.Join(s.modes, " "); got != "major minor patch auto" {
+		t.Errorf("spec() modes = %v", got)
+	}
+	if got := strings.Join(s.flagNames(), " "); got != "--keep-going --git --repo -z --null --help" {
+		t.Errorf("spec() flags = %v", got)
+	}
+	if s.flags[0].value || !s.flags[1].value {
+		t.Errorf("spec() flags = %v", s.flags)
+	}
+
+	cmd, _ = lookup("sort")
+	s = cmd.spec()
+	if len(s.modes) != 0 {
+		t.Errorf("spec() modes = %v", s.modes)
+	}
+	for _, f := range s.flags {
+		if f.value {
+			t.Errorf("spec() flag %s should not take a value", f.name)
+		}
+	}
+}
+
+// Test_command_specFlags checks that every flag of the usage is accepted by its command.
+func Test_command_specFlags(t *testing.T) {
+	for _, cmd := range commands {
+		for _, line := range cmd.usage {
+			s := (&command{names: cmd.names, usage: []string{line}}).spec()
+			var args []string
+			if len(s.modes) == 1 {
+				args = append(args, s.modes[0])
+			}
+			for _, f := range s.flags {
+				if f.name == "--help" {
+					continue
+				}
+				t.Run(cmd.names[0]+" "+f.name, func(t *testing.T) {
+					args := append(args, f.name)
+					if f.value {
+						args = append(args, "x")
+					}
+					_, err := cmd.run(cmd.names[0], args, env{stdin: strings.NewReader("")})
+					if err != nil && strings.Contains(err.Error(), "flag provided but not defined") {
+						t.Errorf("%s does not accept %s: %v", cmd.names[0], f.name, err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func Test_completion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			res, err := completion(shell)
+			if err != nil {
+				t.Fatalf("completion() error = %v", err)
+			}
+			script := res.text
+			for _, cmd := range commands {
+				for _, name := range cmd.names {
+					if !strings.Contains(script, name) {
+						t.Errorf("completion() misses command %s", name)
+					}
+				}
+			}
+			for _, word := range []string{"major minor patch", "all pre build", "reverse", "releases-only", "json"} {
+				if !strings.Contains(script, word) {
+					t.Errorf("completion() misses %s", word)
+				}
+			}
+
+			bin, err := exec.LookPath(shell)
+			if err != nil {
+				t.Skipf("%s not installed", shell)
+			}
+			path := filepath.Join(t.TempDir(), "semver."+shell)
+			if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			out, err := exec.Command(bin, "-n", path).CombinedOutput()
+			if err != nil {
+				t.Errorf("%s -n: %v\n%s", shell, err, out)
+			}
+		})
+	}
+
+	if _, err := completion("powershell"); err == nil {
+		t.Error("completion() of unknown shell should fail")
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nothub/semver"
+	"github.com/nothub/semver/git"
+	"github.com/nothub/semver/manifest"
+)
+
+var errInconsistent = errors.New("versions are inconsistent")
+
+// checkConsistency reads the version of every source and reports if they differ.
+// A source is a file, optionally followed by ":<key>" like Chart.yaml:appVersion,
+// or "git" for the latest version tag. Build metadata is ignored unless requested.
+func checkConsistency(args []string) (result, error) {
+	fs := newFlagSet("check-consistency")
+	build := fs.Bool("build", false, "")
+	repo := fs.String("repo", ".", "")
+	prefix := fs.String("prefix", "v", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) < 2 {
+		return result{}, errUsage
+	}
+
+	var vers []semver.Version
+	var lines []string
+	sources := []sourceJSON{}
+	for _, src := range args {
+		ver, err := readSource(src, *repo, *prefix)
+		if err != nil {
+			return result{text: joinLines(lines), value: sources}, err
+		}
+		vers = append(vers, ver)
+		lines = append(lines, src+": "+ver.String())
+		sources = append(sources, sourceJSON{Source: src, Version: newVersionJSON(ver)})
+	}
+	res := result{text: joinLines(lines), value: sources}
+
+	var mismatches []string
+	for i, ver := range vers[1:] {
+		same := ver.Same(vers[0])
+		if *build {
+			same = semver.CompareBuild(ver, vers[0]) == 0
+		}
+		if !same {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s differs from %s %s", args[i+1], ver.String(), args[0], vers[0].String()))
+		}
+	}
+	if len(mismatches) > 0 {
+		return res, fmt.Errorf("%w: %s", errInconsistent, strings.Join(mismatches, ", "))
+	}
+
+	return res, nil
+}
+
+// readSource reads the version of a file, a file with key or the latest git tag.
+func readSource(src string, repo string, prefix string) (semver.Version, error) {
+	if src == "git" {
+		r, err := git.Open(repo)
+		if err != nil {
+			return semver.Version{}, err
+		}
+		ver, err := r.Latest(prefix, false)
+		if err != nil {
+			return semver.Version{}, fmt.Errorf("%s: %w", src, err)
+		}
+		return ver, nil
+	}
+
+	path, key := src, ""
+	if _, err := os.Stat(src); err != nil {
+		if i := strings.LastIndexByte(src, ':'); i > 0 {
+			path, key = src[:i], src[i+1:]
+		}
+	}
+	f, err := manifest.Read(path, key)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	return f.Version, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_checkConsistency(t *testing.T) {
+	dir := gitRepo(t,
+		[]string{"commit", "-q", "--allow-empty", "-m", "one"},
+		[]string{"tag", "v1.2.3"},
+	)
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	version := write("VERSION", "1.2.3+build.1\n")
+	pkg := write("package.json", `{"version": "1.2.3"}`)
+	chart := write("Chart.yaml", "version: 0.1.0\nappVersion: 1.2.4\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "consistent",
+			args: []string{"--repo", dir, version, pkg, "git"},
+			want: version + ": 1.2.3+build.1\n" + pkg + ": 1.2.3\ngit: 1.2.3\n",
+		},
+		{
+			name:    "build metadata included",
+			args:    []string{"--build", version, pkg},
+			want:    version + ": 1.2.3+build.1\n" + pkg + ": 1.2.3\n",
+			wantErr: "versions are inconsistent",
+		},
+		{
+			name:    "mismatch",
+			args:    []string{pkg, chart + ":appVersion"},
+			want:    pkg + ": 1.2.3\n" + chart + ":appVersion: 1.2.4\n",
+			wantErr: "versions are inconsistent",
+		},
+		{
+			name:    "unknown key",
+			args:    []string{pkg, chart + ":bogus"},
+			want:    pkg + ": 1.2.3\n",
+			wantErr: "version not found",
+		},
+		{
+			name:    "single source",
+			args:    []string{pkg},
+			wantErr: "invalid usage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkConsistency(tt.args)
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkConsistency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.text != tt.want {
+				t.Errorf("checkConsistency() got = %q, want %q", got.text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+var errUnsatisfied = errors.New("no version satisfies the constraint")
+
+// satisfies reports if a version satisfies a constraint.
+func satisfies(constraint string, str string) (bool, error) {
+	c, err := semver.ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	ver, err := semver.Parse(str)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(ver), nil
+}
+
+// filterVersions matches candidates from args, or from stdin if there are none,
+// against a constraint. Mode is one of filter, max-satisfying or min-satisfying.
+func filterVersions(mode string, args []string, stdin io.Reader) (result, error) {
+	if len(args) < 1 {
+		return result{}, errUsage
+	}
+	c, err := semver.ParseConstraint(args[0])
+	if err != nil {
+		return result{}, err
+	}
+	vers, err := readVersions(args[1:], stdin)
+	if err != nil {
+		return result{}, err
+	}
+
+	switch strings.ToLower(mode) {
+	case "filter":
+		return versionsResult(semver.Filter(vers, c), "\n"), nil
+	case "max-satisfying":
+		ver, ok := semver.MaxSatisfying(vers, c)
+		if !ok {
+			return result{}, errUnsatisfied
+		}
+		return versionResult(ver), nil
+	case "min-satisfying":
+		ver, ok := semver.MinSatisfying(vers, c)
+		if !ok {
+			return result{}, errUnsatisfied
+		}
+		return versionResult(ver), nil
+	default:
+		return result{}, errUsage
+	}
+}
+
+// readVersions parses versions from strs, or from the lines of stdin if strs is empty.
+func readVersions(strs []string, stdin io.Reader) ([]semver.Version, error) {
+	if len(strs) == 0 {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		strs = strings.Split(string(data), "\n")
+	}
+	var vers []semver.Version
+	for _, str := range strs {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		ver, err := semver.Parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, str)
+		}
+		vers = append(vers, ver)
+	}
+	return vers, nil
+}
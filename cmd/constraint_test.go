@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_satisfies(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+		wantErr    bool
+	}{
+		{constraint: "^1.2.0", version: "1.4.0", want: true},
+		{constraint: "^1.2.0", version: "2.0.0", want: false},
+		{constraint: ">=1.20 <1.22 || 1.22.x", version: "1.22.5", want: true},
+		{constraint: "bogus", version: "1.0.0", wantErr: true},
+		{constraint: "*", version: "1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			got, err := satisfies(tt.constraint, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("satisfies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("satisfies() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_filterVersions(t *testing.T) {
+	installed := "1.20.14\n1.21.6\n1.22.0-rc.2\n1.22.3\n1.23.0\n"
+	tests := []struct {
+		name    string
+		mode    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "filter stdin",
+			mode:  "filter",
+			args:  []string{"~1.21 || ~1.22"},
+			stdin: installed,
+			want:  "1.21.6\n1.22.3\n",
+		},
+		{
+			name: "filter arguments",
+			mode: "filter",
+			args: []string{">=1.22", "1.21.0", "1.22.1", "1.23.0"},
+			want: "1.22.1\n1.23.0\n",
+		},
+		{
+			name:  "max satisfying",
+			mode:  "max-satisfying",
+			args:  []string{"<1.23"},
+			stdin: installed,
+			want:  "1.22.3",
+		},
+		{
+			name:  "min satisfying",
+			mode:  "min-satisfying",
+			args:  []string{">=1.21"},
+			stdin: installed,
+			want:  "1.21.6",
+		},
+		{
+			name:    "unsatisfied",
+			mode:    "max-satisfying",
+			args:    []string{">=2"},
+			stdin:   installed,
+			wantErr: true,
+		},
+		{
+			name:    "invalid candidate",
+			mode:    "filter",
+			args:    []string{"*"},
+			stdin:   "1.0.0\ngo1.22\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing constraint",
+			mode:    "filter",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterVersions(tt.mode, tt.args, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("filterVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("filterVersions() got = %q, want %q", got.text, tt.want)
+			}
+		})
+	}
+}
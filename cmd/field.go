@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// get returns a single field of a version, one of major, minor, patch, pre or build.
+func get(field string, str string) (result, error) {
+	ver, err := semver.Parse(str)
+	if err != nil {
+		return result{}, err
+	}
+
+	switch strings.ToLower(field) {
+	case "major":
+		return textResult(strconv.Itoa(ver.Major)), nil
+	case "minor":
+		return textResult(strconv.Itoa(ver.Minor)), nil
+	case "patch":
+		return textResult(strconv.Itoa(ver.Patch)), nil
+	case "pre":
+		return textResult(strings.Join(ver.PreRelease, ".")), nil
+	case "build":
+		return textResult(strings.Join(ver.Build, ".")), nil
+	default:
+		return result{}, errUsage
+	}
+}
+
+// fieldRegex validates the values of the fields accepted by set.
+var fieldRegex = map[string]*regexp.Regexp{
+	"major": regexp.MustCompile(`^(0|[1-9]\d*)$`),
+	"minor": regexp.MustCompile(`^(0|[1-9]\d*)$`),
+	"patch": regexp.MustCompile(`^(0|[1-9]\d*)$`),
+	"pre":   regexp.MustCompile(`^((0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(\.(0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?$`),
+	"build": regexp.MustCompile(`^([0-9a-zA-Z-]+(\.[0-9a-zA-Z-]+)*)?$`),
+}
+
+// set replaces a single field of a version, one of major, minor, patch, pre or build.
+// An empty value removes pre-release or build metadata.
+// The value is validated on its own, so it can not spill into other fields.
+func set(field string, value string, str string) (result, error) {
+	ver, err := semver.Parse(str)
+	if err != nil {
+		return result{}, err
+	}
+
+	field = strings.ToLower(field)
+	regex, ok := fieldRegex[field]
+	if !ok {
+		return result{}, errUsage
+	}
+	if !regex.MatchString(value) {
+		return result{}, fmt.Errorf("%w: %s %q", semver.ErrInvalid, field, value)
+	}
+
+	major := strconv.Itoa(ver.Major)
+	minor := strconv.Itoa(ver.Minor)
+	patch := strconv.Itoa(ver.Patch)
+	pre := strings.Join(ver.PreRelease, ".")
+	build := strings.Join(ver.Build, ".")
+
+	switch field {
+	case "major":
+		major = value
+	case "minor":
+		minor = value
+	case "patch":
+		patch = value
+	case "pre":
+		pre = value
+	case "build":
+		build = value
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(major)
+	sb.WriteString(".")
+	sb.WriteString(minor)
+	sb.WriteString(".")
+	sb.WriteString(patch)
+	if pre != "" {
+		sb.WriteString("-")
+		sb.WriteString(pre)
+	}
+	if build != "" {
+		sb.WriteString("+")
+		sb.WriteString(build)
+	}
+
+	res, err := semver.Parse(sb.String())
+	if err != nil {
+		return result{}, fmt.Errorf("%w: %s %q", err, field, value)
+	}
+	return versionResult(res), nil
+}
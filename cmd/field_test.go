@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func Test_get(t *testing.T) {
+	tests := []struct {
+		field   string
+		str     string
+		want    string
+		wantErr bool
+	}{
+		{field: "major", str: "1.2.3", want: "1"},
+		{field: "minor", str: "1.2.3", want: "2"},
+		{field: "patch", str: "1.2.3", want: "3"},
+		{field: "pre", str: "1.2.3-rc.1", want: "rc.1"},
+		{field: "pre", str: "1.2.3", want: ""},
+		{field: "build", str: "1.2.3-rc.1+abc.5", want: "abc.5"},
+		{field: "bogus", str: "1.2.3", wantErr: true},
+		{field: "major", str: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+" "+tt.str, func(t *testing.T) {
+			got, err := get(tt.field, tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("get() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
+
+func Test_set(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   string
+		str     string
+		want    string
+		wantErr bool
+	}{
+		{field: "major", value: "4", str: "1.2.3", want: "4.2.3"},
+		{field: "minor", value: "0", str: "1.2.3-rc.1", want: "1.0.3-rc.1"},
+		{field: "patch", value: "10", str: "1.2.3+b", want: "1.2.10+b"},
+		{field: "pre", value: "rc.2", str: "1.2.3-rc.1+b", want: "1.2.3-rc.2+b"},
+		{field: "pre", value: "", str: "1.2.3-rc.1", want: "1.2.3"},
+		{field: "build", value: "0a1b2c3", str: "1.2.3", want: "1.2.3+0a1b2c3"},
+		{field: "major", value: "01", str: "1.2.3", wantErr: true},
+		{field: "minor", value: "-1", str: "1.2.3", wantErr: true},
+		{field: "patch", value: "x", str: "1.2.3", wantErr: true},
+		{field: "pre", value: "rc.01", str: "1.2.3", wantErr: true},
+		{field: "pre", value: "rc_1", str: "1.2.3", wantErr: true},
+		{field: "build", value: "a+b", str: "1.2.3", wantErr: true},
+		{field: "patch", value: "4-rc.1", str: "1.2.3", wantErr: true},
+		{field: "minor", value: "2+b", str: "1.2.3", wantErr: true},
+		{field: "major", value: "1.2", str: "1.2.3", wantErr: true},
+		{field: "pre", value: "rc.1+sha", str: "1.2.3", wantErr: true},
+		{field: "pre", value: "rc..1", str: "1.2.3", wantErr: true},
+		{field: "build", value: "a.", str: "1.2.3", wantErr: true},
+		{field: "build", value: "-", str: "1.2.3-rc.1", want: "1.2.3-rc.1+-"},
+		{field: "bogus", value: "1", str: "1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+" "+tt.value+" "+tt.str, func(t *testing.T) {
+			got, err := set(tt.field, tt.value, tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("set() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("set() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/nothub/semver/git"
+)
+
+func gitTags(args []string) (result, error) {
+	fs := newFlagSet("git")
+	repo := fs.String("repo", ".", "")
+	prefix := fs.String("prefix", "v", "")
+	reachable := fs.Bool("reachable", false, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) != 1 {
+		return result{}, errUsage
+	}
+	mode := strings.ToLower(args[0])
+	if mode != "latest" && mode != "list" {
+		return result{}, errUsage
+	}
+
+	r, err := git.Open(*repo)
+	if err != nil {
+		return result{}, err
+	}
+
+	switch mode {
+	case "latest":
+		ver, err := r.Latest(*prefix, *reachable)
+		if err != nil {
+			return result{}, err
+		}
+		return versionResult(ver), nil
+	default:
+		vers, err := r.Versions(*prefix, *reachable)
+		if err != nil {
+			return result{}, err
+		}
+		return versionsResult(vers, "\n"), nil
+	}
+}
+
+// gitLog returns the commits of a revision range like "v1.2.3..HEAD".
+// A single revision is treated as the start of a range ending at HEAD.
+func gitLog(repo string, rng string) ([]git.Commit, error) {
+	r, err := git.Open(repo)
+	if err != nil {
+		return nil, err
+	}
+	from, to, ok := strings.Cut(rng, "..")
+	if !ok || to == "" {
+		to = "HEAD"
+	}
+	return r.Log(from, to)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// gitRepo creates a repository and runs the given git commands in it.
+func gitRepo(t *testing.T, cmds ...[]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+	dir := t.TempDir()
+	for _, args := range append([][]string{{"init", "-q", "-b", "main"}}, cmds...) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_CONFIG_GLOBAL=/dev/null",
+			"GIT_CONFIG_NOSYSTEM=1",
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@example.org",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@example.org",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %s\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	return dir
+}
+
+func Test_gitTags(t *testing.T) {
+	dir := gitRepo(t,
+		[]string{"commit", "-q", "--allow-empty", "-m", "one"},
+		[]string{"tag", "v1.0.0"},
+		[]string{"commit", "-q", "--allow-empty", "-m", "two"},
+		[]string{"tag", "v1.1.0-rc.1"},
+		[]string{"tag", "api/v0.3.0"},
+	)
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "latest",
+			args: []string{"latest", "--repo", dir},
+			want: "1.0.0",
+		},
+		{
+			name: "list",
+			args: []string{"list", "--repo", dir},
+			want: "1.1.0-rc.1\n1.0.0\n",
+		},
+		{
+			name: "list with prefix",
+			args: []string{"list", "--repo", dir, "--prefix", "api/v"},
+			want: "0.3.0\n",
+		},
+		{
+			name:    "invalid mode",
+			args:    []string{"bogus", "--repo", dir},
+			wantErr: true,
+		},
+		{
+			name:    "no repository",
+			args:    []string{"latest", "--repo", t.TempDir()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gitTags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("gitTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("gitTags() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
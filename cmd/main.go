@@ -1,120 +1,40 @@
 package main
 
 import (
-	"errors"
-	"fmt"
-	"log"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/nothub/semver"
 )
 
-const usage string = `semver - utilities for semantic versioning
-
-Options:
-    -h, --help   Show help
-
-Commands:
-    next - Bump to the next version
-    Usage: semver [opts...] next (major|minor|patch) <version>
-
-    strip - Remove pre-release or build metadata
-    Usage: semver [opts...] strip (all|pre|build) <version>
-
-    valid - Check input for conformity
-    Usage: semver [opts...] valid <version>
-
-    tags - Expand to container tags
-    Usage: semver [opts...] tags <version>
-`
-
-var errUsage = errors.New("invalid usage")
-
 func main() {
-	log.SetFlags(0)
-
-	args := os.Args[1:]
-
-	mustLen(args, 1)
-	if args[0] == "-h" || args[0] == "--help" {
-		fmt.Print(usage)
-		os.Exit(0)
-	}
-
-	cmd := strings.ToLower(args[0])
-	args = args[1:]
-
-	var out string
-	var err error
-
-	switch cmd {
-	case "next":
-		mustLen(args, 2)
-		out, err = next(args[0], args[1])
-	case "strip":
-		mustLen(args, 2)
-		out, err = strip(args[0], args[1])
-	case "valid":
-		mustLen(args, 1)
-		err = valid(args[0])
-	case "tags":
-		mustLen(args, 1)
-		out, err = tags(args[0])
-	default:
-		err = errUsage
-	}
-
-	if err != nil {
-		if errors.Is(err, errUsage) {
-			log.Print(usage)
-			os.Exit(1)
-		} else {
-			log.Fatalln(err.Error())
-		}
-	}
-
-	fmt.Print(out)
-}
-
-func mustLen(args []string, minLen int) {
-	if len(args) < minLen {
-		log.Print(usage)
-		os.Exit(1)
-	}
+	os.Exit(run(os.Args[1:], env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
 }
 
-func next(mode string, str string) (string, error) {
+func next(mode string, str string) (result, error) {
 	ver, err := semver.Parse(str)
 	if err != nil {
-		return "", err
+		return result{}, err
 	}
 
-	ver.PreRelease = nil
-	ver.Build = nil
-
 	switch strings.ToLower(mode) {
 	case "major":
-		ver.Major++
-		ver.Minor = 0
-		ver.Patch = 0
+		ver = ver.NextMajor()
 	case "minor":
-		ver.Minor++
-		ver.Patch = 0
+		ver = ver.NextMinor()
 	case "patch":
-		ver.Patch++
+		ver = ver.NextPatch()
 	default:
-		return "", errUsage
+		return result{}, errUsage
 	}
 
-	return ver.String(), nil
+	return versionResult(ver), nil
 }
 
-func strip(mode string, str string) (string, error) {
+func strip(mode string, str string) (result, error) {
 	ver, err := semver.Parse(str)
 	if err != nil {
-		return "", err
+		return result{}, err
 	}
 
 	switch strings.ToLower(mode) {
@@ -126,63 +46,40 @@ func strip(mode string, str string) (string, error) {
 	case "build":
 		ver.Build = nil
 	default:
-		return "", errUsage
+		return result{}, errUsage
 	}
 
-	return ver.String(), nil
-}
-
-func valid(str string) error {
-	_, err := semver.Parse(str)
-	return err
+	return versionResult(ver), nil
 }
 
-func tags(str string) (string, error) {
+// valid parses a version, the text output is empty.
+func valid(str string) (result, error) {
 	ver, err := semver.Parse(str)
 	if err != nil {
-		return "", err
+		return result{}, err
 	}
+	return result{value: newVersionJSON(ver), silent: true}, nil
+}
 
-	var vers []string
-
-	// major + minor + patch
-	{
-		sb := strings.Builder{}
-		sb.WriteString(strconv.Itoa(ver.Major))
-		sb.WriteString(".")
-		sb.WriteString(strconv.Itoa(ver.Minor))
-		sb.WriteString(".")
-		sb.WriteString(strconv.Itoa(ver.Patch))
-		if len(ver.PreRelease) > 0 {
-			sb.WriteString("-")
-			sb.WriteString(strings.Join(ver.PreRelease, "."))
-		}
-		vers = append(vers, sb.String())
+func describe(args []string) (result, error) {
+	fs := newFlagSet("describe")
+	tmpl := fs.String("template", semver.DescribeTemplate, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
 	}
-
-	// major + minor
-	{
-		sb := strings.Builder{}
-		sb.WriteString(strconv.Itoa(ver.Major))
-		sb.WriteString(".")
-		sb.WriteString(strconv.Itoa(ver.Minor))
-		if len(ver.PreRelease) > 0 {
-			sb.WriteString("-")
-			sb.WriteString(strings.Join(ver.PreRelease, "."))
-		}
-		vers = append(vers, sb.String())
+	if len(args) != 1 {
+		return result{}, errUsage
 	}
 
-	// major only
-	{
-		sb := strings.Builder{}
-		sb.WriteString(strconv.Itoa(ver.Major))
-		if len(ver.PreRelease) > 0 {
-			sb.WriteString("-")
-			sb.WriteString(strings.Join(ver.PreRelease, "."))
-		}
-		vers = append(vers, sb.String())
+	d, err := semver.ParseDescribe(args[0])
+	if err != nil {
+		return result{}, err
+	}
+	ver, err := d.Version(*tmpl)
+	if err != nil {
+		return result{}, err
 	}
 
-	return strings.Join(vers, " "), nil
+	return versionResult(ver), nil
 }
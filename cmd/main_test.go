@@ -55,8 +55,8 @@ func Test_next(t *testing.T) {
 				t.Errorf("next() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("next() got = %v, want %v", got, tt.want)
+			if got.text != tt.want {
+				t.Errorf("next() got = %v, want %v", got.text, tt.want)
 			}
 		})
 	}
@@ -116,8 +116,8 @@ func Test_strip(t *testing.T) {
 				t.Errorf("strip() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("strip() got = %v, want %v", got, tt.want)
+			if got.text != tt.want {
+				t.Errorf("strip() got = %v, want %v", got.text, tt.want)
 			}
 		})
 	}
@@ -145,55 +145,55 @@ func Test_valid(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := valid(tt.args.str); (err != nil) != tt.wantErr {
+			if _, err := valid(tt.args.str); (err != nil) != tt.wantErr {
 				t.Errorf("valid() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
-func Test_tags(t *testing.T) {
+func Test_describe(t *testing.T) {
 	tests := []struct {
 		name    string
-		str     string
+		args    []string
 		want    string
 		wantErr bool
 	}{
 		{
-			name: "release",
-			str:  "1.2.3",
-			want: "1.2.3 1.2 1",
+			name: "untagged dirty build",
+			args: []string{"v1.2.3-14-gabc1234-dirty"},
+			want: "1.2.4-dev.14+gabc1234.dirty",
 		},
 		{
-			name: "pre-release appended to all three tags",
-			str:  "1.2.3-alpha.1",
-			want: "1.2.3-alpha.1 1.2-alpha.1 1-alpha.1",
+			name: "tagged build",
+			args: []string{"v1.2.3-0-gabc1234"},
+			want: "1.2.3",
 		},
 		{
-			name: "build metadata stripped",
-			str:  "1.2.3+build.5",
-			want: "1.2.3 1.2 1",
+			name: "custom template",
+			args: []string{"--template", "{{.Major}}.{{.Minor}}.{{.Patch}}-next.{{.Distance}}", "v1.2.3-14-gabc1234"},
+			want: "1.2.4-next.14",
 		},
 		{
-			name: "pre-release kept build stripped",
-			str:  "1.2.3-rc.1+build.5",
-			want: "1.2.3-rc.1 1.2-rc.1 1-rc.1",
+			name:    "invalid describe output",
+			args:    []string{"abc1234"},
+			wantErr: true,
 		},
 		{
-			name:    "invalid version",
-			str:     "-0.0.0",
+			name:    "missing argument",
+			args:    []string{},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tags(tt.str)
+			got, err := describe(tt.args)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("tags() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("describe() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("tags() got = %v, want %v", got, tt.want)
+			if got.text != tt.want {
+				t.Errorf("describe() got = %v, want %v", got.text, tt.want)
 			}
 		})
 	}
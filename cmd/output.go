@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// document is the JSON representation of a command invocation, the schema is documented in README.md.
+type document struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	OK      bool     `json:"ok"`
+	Result  any      `json:"result"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// versionJSON is the JSON representation of a semver.Version.
+type versionJSON struct {
+	Version    string   `json:"version"`
+	Major      int      `json:"major"`
+	Minor      int      `json:"minor"`
+	Patch      int      `json:"patch"`
+	PreRelease []string `json:"pre_release"`
+	Build      []string `json:"build"`
+	Release    bool     `json:"release"`
+}
+
+// bumpJSON is the JSON representation of a file bumped by bump-file.
+type bumpJSON struct {
+	Path string      `json:"path"`
+	From versionJSON `json:"from"`
+	To   versionJSON `json:"to"`
+	Diff string      `json:"diff"`
+}
+
+// sourceJSON is the JSON representation of a version read by check-consistency.
+type sourceJSON struct {
+	Source  string      `json:"source"`
+	Version versionJSON `json:"version"`
+}
+
+// batchJSON is the JSON representation of a single input of a command in batch mode.
+type batchJSON struct {
+	Input  string `json:"input"`
+	OK     bool   `json:"ok"`
+	Result any    `json:"result"`
+	Error  string `json:"error,omitempty"`
+	// line of stdin, omitted for arguments
+	Line int `json:"line,omitempty"`
+}
+
+// pruneJSON is the JSON representation of the tags planned by prune.
+type pruneJSON struct {
+	Keep   []string `json:"keep"`
+	Delete []string `json:"delete"`
+}
+
+// backportJSON is the JSON representation of a release branch listed by backport.
+type backportJSON struct {
+	Branch string      `json:"branch"`
+	Next   versionJSON `json:"next"`
+}
+
+// supportJSON is the JSON representation of the status reported by support-status.
+type supportJSON struct {
+	Status      string       `json:"status"`
+	Replacement *versionJSON `json:"replacement"`
+}
+
+func newVersionJSON(ver semver.Version) versionJSON {
+	return versionJSON{
+		Version:    ver.String(),
+		Major:      ver.Major,
+		Minor:      ver.Minor,
+		Patch:      ver.Patch,
+		PreRelease: append([]string{}, ver.PreRelease...),
+		Build:      append([]string{}, ver.Build...),
+		Release:    ver.IsRelease(),
+	}
+}
+
+// result is the outcome of a command, the text output and the typed value
+// serialized as result of the JSON document.
+type result struct {
+	text  string
+	value any
+	// silent results have no text, not even an empty line in batch mode
+	silent bool
+}
+
+// joinLines joins strs to lines, each terminated by a newline.
+func joinLines(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	return strings.Join(strs, "\n") + "\n"
+}
+
+// textResult returns a result holding str as text and as value.
+func textResult(str string) result {
+	return result{text: str, value: str}
+}
+
+// versionResult returns a result holding a single version.
+func versionResult(ver semver.Version) result {
+	return result{text: ver.String(), value: newVersionJSON(ver)}
+}
+
+// versionsResult returns a result holding versions, each terminated by sep in the text output.
+func versionsResult(vers []semver.Version, sep string) result {
+	var sb strings.Builder
+	values := []versionJSON{}
+	for _, ver := range vers {
+		sb.WriteString(ver.String())
+		sb.WriteString(sep)
+		values = append(values, newVersionJSON(ver))
+	}
+	return result{text: sb.String(), value: values}
+}
+
+// newDocument converts the result of a command to a document.
+func newDocument(cmd string, args []string, res result, err error) document {
+	doc := document{
+		Command: cmd,
+		Args:    append([]string{}, args...),
+		OK:      err == nil,
+		Result:  res.value,
+	}
+
+	switch {
+	case errors.Is(err, errFalse):
+		doc.OK = true
+		doc.Result = false
+	case err != nil:
+		doc.Error = err.Error()
+	}
+
+	return doc
+}
+
+// writeDocument writes a document as a single line of JSON.
+func writeDocument(w io.Writer, doc document) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(doc)
+}
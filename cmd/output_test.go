@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func Test_newDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  string
+		args []string
+		res  result
+		err  error
+		want string
+	}{
+		{
+			name: "version result",
+			cmd:  "next",
+			args: []string{"minor", "1.2.3"},
+			res:  versionResult(semver.Version{Major: 1, Minor: 3}),
+			want: `{"command":"next","args":["minor","1.2.3"],"ok":true,"result":{"version":"1.3.0","major":1,"minor":3,"patch":0,"pre_release":[],"build":[],"release":true}}`,
+		},
+		{
+			name: "version list",
+			cmd:  "sort",
+			args: []string{"-z"},
+			res:  versionsResult([]semver.Version{{Major: 1}, {Major: 2}}, "\x00"),
+			want: `{"command":"sort","args":["-z"],"ok":true,"result":[{"version":"1.0.0","major":1,"minor":0,"patch":0,"pre_release":[],"build":[],"release":true},{"version":"2.0.0","major":2,"minor":0,"patch":0,"pre_release":[],"build":[],"release":true}]}`,
+		},
+		{
+			name: "empty version list",
+			cmd:  "filter",
+			args: []string{">3"},
+			res:  versionsResult(nil, "\n"),
+			want: `{"command":"filter","args":[">3"],"ok":true,"result":[]}`,
+		},
+		{
+			name: "text result",
+			cmd:  "get",
+			args: []string{"pre", "1.0.0-rc.1"},
+			res:  textResult("rc.1"),
+			want: `{"command":"get","args":["pre","1.0.0-rc.1"],"ok":true,"result":"rc.1"}`,
+		},
+		{
+			name: "predicate true",
+			cmd:  "gt",
+			args: []string{"2.0.0", "1.0.0"},
+			res:  result{value: true},
+			want: `{"command":"gt","args":["2.0.0","1.0.0"],"ok":true,"result":true}`,
+		},
+		{
+			name: "predicate false",
+			cmd:  "satisfies",
+			args: []string{"^2", "1.0.0"},
+			err:  errFalse,
+			want: `{"command":"satisfies","args":["^2","1.0.0"],"ok":true,"result":false}`,
+		},
+		{
+			name: "error",
+			cmd:  "strip",
+			args: []string{"all", "foo"},
+			err:  errors.New("invalid semver string"),
+			want: `{"command":"strip","args":["all","foo"],"ok":false,"result":null,"error":"invalid semver string"}`,
+		},
+		{
+			name: "usage error without command",
+			err:  errUsage,
+			want: `{"command":"","args":[],"ok":false,"result":null,"error":"invalid usage"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := writeDocument(&sb, newDocument(tt.cmd, tt.args, tt.res, tt.err)); err != nil {
+				t.Fatalf("writeDocument() error = %v", err)
+			}
+			if got := strings.TrimSuffix(sb.String(), "\n"); got != tt.want {
+				t.Errorf("newDocument() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_commandResults(t *testing.T) {
+	version := filepath.Join(t.TempDir(), "VERSION")
+	if err := os.WriteFile(version, []byte("1.2.3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{
+			name: "valid returns parsed fields",
+			args: []string{"valid", "1.0.0-rc.1+b"},
+			want: `{"version":"1.0.0-rc.1+b","major":1,"minor":0,"patch":0,"pre_release":["rc","1"],"build":["b"],"release":false}`,
+		},
+		{
+			name: "valid with flag",
+			args: []string{"valid", "1.2.3", "--keep-going"},
+			want: `{"version":"1.2.3","major":1,"minor":2,"patch":3,"pre_release":[],"build":[],"release":true}`,
+		},
+		{
+			name: "bump file",
+			args: []string{"bump-file", "minor", "--dry-run", version},
+			want: `[{"path":"` + version + `","from":{"version":"1.2.3","major":1,"minor":2,"patch":3,"pre_release":[],"build":[],"release":true},"to":{"version":"1.3.0","major":1,"minor":3,"patch":0,"pre_release":[],"build":[],"release":true},"diff":"--- a/` + version + `\n+++ b/` + version + `\n@@ -1 +1 @@\n-1.2.3\n+1.3.0\n"}]`,
+		},
+		{
+			name: "compare",
+			args: []string{"compare", "1.0.0", "2.0.0"},
+			want: `-1`,
+		},
+		{
+			name: "backport branches",
+			args: []string{"backport", "1.0.0", "1.0.2"},
+			want: `[{"branch":"release/1.0","next":{"version":"1.0.3","major":1,"minor":0,"patch":3,"pre_release":[],"build":[],"release":true}}]`,
+		},
+		{
+			name: "support status",
+			args: []string{"support-status", "1.0.0", "1.0.1"},
+			want: `{"status":"deprecated","replacement":{"version":"1.0.1","major":1,"minor":0,"patch":1,"pre_release":[],"build":[],"release":true}}`,
+		},
+		{
+			name: "support status without replacement",
+			args: []string{"support-status", "1.0.1", "1.0.1"},
+			want: `{"status":"supported","replacement":null}`,
+		},
+		{
+			name: "prune plan",
+			args: []string{"prune", "1.0.1", "1.0.0"},
+			want: `{"keep":["1.0.1"],"delete":["1.0.0"]}`,
+		},
+		{
+			name: "prune delete only",
+			args: []string{"prune", "--delete", "1.0.1"},
+			want: `[]`,
+		},
+		{
+			name: "prune delete with value",
+			args: []string{"prune", "--delete=true", "1.1.0", "1.0.1", "1.0.0"},
+			want: `["1.0.0"]`,
+		},
+		{
+			name: "tags list",
+			args: []string{"tags", "1.2.3"},
+			want: `["1.2.3","1.2","1"]`,
+		},
+		{
+			name: "tags with separator",
+			args: []string{"tags", "--separator", ";", "1.2.3"},
+			want: `["1.2.3","1.2","1"]`,
+		},
+		{
+			name: "tags with format",
+			args: []string{"tags", "--format", "{{.Name}} x", "1.2.3"},
+			want: `["1.2.3 x","1.2 x","1 x"]`,
+		},
+		{
+			name:  "sort",
+			args:  []string{"sort", "-z"},
+			stdin: "2.0.0\x001.0.0\x00",
+			want:  `[{"version":"1.0.0","major":1,"minor":0,"patch":0,"pre_release":[],"build":[],"release":true},{"version":"2.0.0","major":2,"minor":0,"patch":0,"pre_release":[],"build":[],"release":true}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, ok := lookup(tt.args[0])
+			if !ok {
+				t.Fatalf("lookup() unknown command %s", tt.args[0])
+			}
+			res, err := cmd.run(tt.args[0], tt.args[1:], env{stdin: strings.NewReader(tt.stdin), stderr: &strings.Builder{}})
+			if err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			got, err := json.Marshal(res.value)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("run() result = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// prune plans the retention of container tags from args, or from stdin if there are none.
+func prune(args []string, stdin io.Reader) (result, error) {
+	fs := newFlagSet("prune")
+	var policy semver.RetentionPolicy
+	fs.IntVar(&policy.Majors, "majors", -1, "")
+	fs.IntVar(&policy.Minors, "minors", -1, "")
+	fs.IntVar(&policy.PreReleases, "pre-releases", -1, "")
+	fs.StringVar(&policy.Prefix, "prefix", "", "")
+	fs.StringVar(&policy.Variant, "variant", "", "")
+	deleteOnly := fs.Bool("delete", false, "")
+	strs, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+
+	if len(strs) == 0 {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return result{}, err
+		}
+		strs = strings.Split(string(data), "\n")
+	}
+
+	var tags []string
+	for _, str := range strs {
+		if str = strings.TrimSpace(str); str != "" {
+			tags = append(tags, str)
+		}
+	}
+
+	keep, drop := policy.Prune(tags)
+	plan := pruneJSON{Keep: append([]string{}, keep...), Delete: append([]string{}, drop...)}
+	if *deleteOnly {
+		return result{text: joinLines(drop), value: plan.Delete}, nil
+	}
+
+	var lines []string
+	for _, tag := range tags {
+		if len(keep) > 0 && keep[0] == tag {
+			lines = append(lines, "keep "+tag)
+			keep = keep[1:]
+		} else {
+			lines = append(lines, "delete "+tag)
+		}
+	}
+	return result{text: joinLines(lines), value: plan}, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_prune(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "older patches",
+			args: []string{"1.2.1", "1.2.0", "1.1.0"},
+			want: "keep 1.2.1\ndelete 1.2.0\nkeep 1.1.0\n",
+		},
+		{
+			name: "policy",
+			args: []string{"--majors", "1", "--minors", "1", "--pre-releases", "1", "latest", "1", "2.0.0-rc.2", "2.0.0-rc.1", "1.2.1", "1.1.0", "0.9.0"},
+			want: "keep latest\nkeep 1\nkeep 2.0.0-rc.2\ndelete 2.0.0-rc.1\nkeep 1.2.1\ndelete 1.1.0\ndelete 0.9.0\n",
+		},
+		{
+			name: "variant",
+			args: []string{"--variant", "alpine", "--pre-releases", "0", "1.3.0", "1.3.0-alpine", "1.3.0-rc.1-alpine"},
+			want: "keep 1.3.0\nkeep 1.3.0-alpine\ndelete 1.3.0-rc.1-alpine\n",
+		},
+		{
+			name: "variant tags without variant are pre-releases",
+			args: []string{"--pre-releases", "1", "1.3.0", "1.3.0-alpine", "1.2.0-alpine", "1.2.0"},
+			want: "keep 1.3.0\nkeep 1.3.0-alpine\ndelete 1.2.0-alpine\nkeep 1.2.0\n",
+		},
+		{
+			name: "prefix",
+			args: []string{"--prefix", "v", "v1.2.1", "v1.2.0", "1.2.0"},
+			want: "keep v1.2.1\ndelete v1.2.0\nkeep 1.2.0\n",
+		},
+		{
+			name:  "stdin",
+			args:  []string{"--majors", "0", "--delete"},
+			stdin: "1.0.0\n\nedge\n0.1.0\n",
+			want:  "1.0.0\n0.1.0\n",
+		},
+		{
+			name:    "invalid limit",
+			args:    []string{"--majors", "x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prune(tt.args, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("prune() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("prune() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
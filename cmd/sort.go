@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// sortVersions sorts versions from args, or from stdin if there are none.
+func sortVersions(args []string, stdin io.Reader, stderr io.Writer) (result, error) {
+	fs := newFlagSet("sort")
+	reverse := fs.Bool("reverse", false, "")
+	unique := fs.Bool("unique", false, "")
+	releases := fs.Bool("releases-only", false, "")
+	skip := fs.Bool("skip-invalid", false, "")
+	null := fs.Bool("null", false, "")
+	fs.BoolVar(null, "z", false, "")
+	strs, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+
+	sep := "\n"
+	if *null {
+		sep = "\x00"
+	}
+
+	if len(strs) == 0 {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return result{}, err
+		}
+		strs = strings.Split(string(data), sep)
+	}
+
+	var vers []semver.Version
+	seen := make(map[string]bool)
+	for _, str := range strs {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		ver, err := semver.Parse(str)
+		if err != nil {
+			if *skip {
+				_, _ = fmt.Fprintf(stderr, "skipping %q: %s\n", str, err)
+				continue
+			}
+			return result{}, fmt.Errorf("%w: %q", err, str)
+		}
+		if *releases && !ver.IsRelease() {
+			continue
+		}
+		if *unique {
+			if seen[ver.String()] {
+				continue
+			}
+			seen[ver.String()] = true
+		}
+		vers = append(vers, ver)
+	}
+
+	if *reverse {
+		vers = semver.SortDesc(vers)
+	} else {
+		vers = semver.SortAsc(vers)
+	}
+
+	return versionsResult(vers, sep), nil
+}
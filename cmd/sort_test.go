@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_sortVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		warn    string
+		wantErr bool
+	}{
+		{
+			name: "arguments",
+			args: []string{"1.10.0", "1.2.0", "1.0.0", "1.0.0-rc.1"},
+			want: "1.0.0-rc.1\n1.0.0\n1.2.0\n1.10.0\n",
+		},
+		{
+			name:  "stdin",
+			stdin: "1.10.0\n1.0.0-rc.10\n1.0.0-rc.2\n\n",
+			want:  "1.0.0-rc.2\n1.0.0-rc.10\n1.10.0\n",
+		},
+		{
+			name: "reverse",
+			args: []string{"--reverse", "1.0.0", "2.0.0", "1.5.0"},
+			want: "2.0.0\n1.5.0\n1.0.0\n",
+		},
+		{
+			name: "unique",
+			args: []string{"--unique", "1.0.0", "1.0.0", "1.0.0+build", "0.1.0"},
+			want: "0.1.0\n1.0.0\n1.0.0+build\n",
+		},
+		{
+			name: "releases only",
+			args: []string{"--releases-only", "1.0.0", "1.1.0-rc.1", "0.9.0"},
+			want: "0.9.0\n1.0.0\n",
+		},
+		{
+			name:  "skip invalid",
+			args:  []string{"--skip-invalid"},
+			stdin: "1.0.0\nv1.1.0\n0.1.0\n",
+			want:  "0.1.0\n1.0.0\n",
+			warn:  "skipping \"v1.1.0\": invalid semver string\n",
+		},
+		{
+			name:    "strict",
+			stdin:   "1.0.0\nv1.1.0\n",
+			wantErr: true,
+		},
+		{
+			name:  "nul delimited",
+			args:  []string{"-z"},
+			stdin: "1.1.0\x001.0.0\x00",
+			want:  "1.0.0\x001.1.0\x00",
+		},
+		{
+			name: "empty",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stderr := &bytes.Buffer{}
+			got, err := sortVersions(tt.args, strings.NewReader(tt.stdin), stderr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sortVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("sortVersions() got = %q, want %q", got.text, tt.want)
+			}
+			if stderr.String() != tt.warn {
+				t.Errorf("sortVersions() warn = %q, want %q", stderr.String(), tt.warn)
+			}
+		})
+	}
+}
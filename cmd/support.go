@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nothub/semver"
+)
+
+// supportStatus reports the support status of a version and the version to upgrade to.
+// The released versions are read from args following the version, or from stdin if there are none.
+func supportStatus(args []string, stdin io.Reader) (result, error) {
+	fs := newFlagSet("support-status")
+	var policy semver.SupportPolicy
+	fs.IntVar(&policy.Majors, "majors", -1, "")
+	fs.IntVar(&policy.Minors, "minors", -1, "")
+	eol := fs.String("eol", "", "")
+	date := fs.String("date", "", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return result{}, err
+	}
+	if len(args) < 1 {
+		return result{}, errUsage
+	}
+
+	ver, err := semver.Parse(args[0])
+	if err != nil {
+		return result{}, fmt.Errorf("%w: %q", err, args[0])
+	}
+
+	if *date != "" && *eol == "" {
+		return result{}, fmt.Errorf("%w: --date requires --eol", errUsage)
+	}
+
+	var support semver.Support = &policy
+	if *eol != "" {
+		conflict := false
+		fs.Visit(func(f *flag.Flag) {
+			conflict = conflict || f.Name == "majors" || f.Name == "minors"
+		})
+		if conflict {
+			return result{}, fmt.Errorf("%w: --eol conflicts with --majors and --minors", errUsage)
+		}
+		now := time.Now()
+		if *date != "" {
+			if now, err = time.Parse(time.DateOnly, *date); err != nil {
+				return result{}, err
+			}
+		}
+		f, err := os.Open(*eol)
+		if err != nil {
+			return result{}, err
+		}
+		defer f.Close()
+		if support, err = semver.ParseEOLTable(f, now); err != nil {
+			return result{}, err
+		}
+	}
+
+	vers, err := readVersions(args[1:], stdin)
+	if err != nil {
+		return result{}, err
+	}
+
+	status := semver.CheckSupport(support, vers, ver)
+	res := supportJSON{Status: string(status.Status)}
+	if status.Replacement == nil {
+		return result{text: res.Status, value: res}, nil
+	}
+	replacement := newVersionJSON(*status.Replacement)
+	res.Replacement = &replacement
+	return result{text: res.Status + " " + replacement.Version, value: res}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_supportStatus(t *testing.T) {
+	eol := filepath.Join(t.TempDir(), "eol")
+	if err := os.WriteFile(eol, []byte("# line eol\n1.2 2025-01-01\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	released := []string{"2.1.0", "2.0.1", "1.3.2", "1.3.1", "1.2.7"}
+
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "supported",
+			args: append([]string{"2.1.0"}, released...),
+			want: "supported",
+		},
+		{
+			name: "deprecated",
+			args: append([]string{"1.3.1"}, released...),
+			want: "deprecated 1.3.2",
+		},
+		{
+			name:  "policy from stdin",
+			args:  []string{"--majors", "2", "--minors", "1", "1.2.7"},
+			stdin: strings.Join(released, "\n"),
+			want:  "eol 1.3.2",
+		},
+		{
+			name: "eol table",
+			args: append([]string{"--eol", eol, "--date", "2025-01-01", "1.2.7"}, released...),
+			want: "eol 1.3.2",
+		},
+		{
+			name: "eol table before eol",
+			args: append([]string{"--eol", eol, "--date", "2024-12-31", "1.2.7"}, released...),
+			want: "supported",
+		},
+		{
+			name:    "eol table and policy",
+			args:    append([]string{"--eol", eol, "--majors", "1", "1.2.7"}, released...),
+			wantErr: true,
+		},
+		{
+			name:    "date without eol table",
+			args:    append([]string{"--date", "2025-01-01", "1.2.7"}, released...),
+			wantErr: true,
+		},
+		{
+			name:    "missing eol table",
+			args:    append([]string{"--eol", filepath.Join(t.TempDir(), "missing"), "1.2.7"}, released...),
+			wantErr: true,
+		},
+		{
+			name:    "missing version",
+			args:    []string{"--majors", "1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := supportStatus(tt.args, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("supportStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("supportStatus() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/nothub/semver"
+)
+
+// tagOptions configure the tags command.
+type tagOptions struct {
+	plan semver.TagPlan
+	// separator of the tags, a space if empty
+	separator string
+	// renders every tag, the name of the tag is used if nil
+	format *template.Template
+}
+
+// separatorReplacer resolves escape sequences in separators, so newlines can be passed without shell quoting.
+var separatorReplacer = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\\`, `\`)
+
+// parseFormat compiles the text/template of the --format flag.
+func parseFormat(str string) (*template.Template, error) {
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", semver.ErrTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// readPublished parses published versions from flag values, separated by commas,
+// and from the lines of a file or stdin for "-".
+func readPublished(strs []string, path string, stdin io.Reader) ([]semver.Version, error) {
+	var all []string
+	for _, str := range strs {
+		all = append(all, strings.Split(str, ",")...)
+	}
+	if path != "" {
+		r := stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			r = f
+		}
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			all = append(all, sc.Text())
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return readVersions(all, strings.NewReader(""))
+}
+
+// tags expands a version to container tags according to the tag plan
+// and joins the tags, rendered with the format if set, by the separator.
+func tags(str string, opts tagOptions) (result, error) {
+	ver, err := semver.Parse(str)
+	if err != nil {
+		return result{}, err
+	}
+	plan, err := opts.plan.Tags(ver)
+	if err != nil {
+		return result{}, err
+	}
+
+	strs := []string{}
+	for _, tag := range plan {
+		if opts.format == nil {
+			strs = append(strs, tag.Name)
+			continue
+		}
+		sb := strings.Builder{}
+		if err := opts.format.Execute(&sb, &tag); err != nil {
+			return result{}, fmt.Errorf("%w: %w", semver.ErrTemplate, err)
+		}
+		strs = append(strs, sb.String())
+	}
+
+	sep := opts.separator
+	if sep == "" {
+		sep = " "
+	}
+	return result{text: strings.Join(strs, sep), value: strs}, nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func Test_tags(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		opts    tagOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "release",
+			str:  "1.2.3",
+			want: "1.2.3 1.2 1",
+		},
+		{
+			name: "pre-release tagged exact only by default",
+			str:  "1.2.3-alpha.1",
+			want: "1.2.3-alpha.1",
+		},
+		{
+			name: "pre-release channel floating",
+			str:  "1.2.3-alpha.1",
+			opts: tagOptions{plan: semver.TagPlan{PreRelease: semver.PreReleaseChannelFloating}},
+			want: "1.2.3-alpha.1 1.2-alpha 1-alpha alpha",
+		},
+		{
+			name: "pre-release appended to all three tags",
+			str:  "1.2.3-alpha.1",
+			opts: tagOptions{plan: semver.TagPlan{PreRelease: semver.PreReleaseFloating}},
+			want: "1.2.3-alpha.1 1.2-alpha.1 1-alpha.1",
+		},
+		{
+			name: "zero major",
+			str:  "0.3.1",
+			want: "0.3.1 0.3 0",
+		},
+		{
+			name: "no major for zero",
+			str:  "0.3.1",
+			opts: tagOptions{plan: semver.TagPlan{NoMajorForZero: true}},
+			want: "0.3.1 0.3",
+		},
+		{
+			name: "build metadata stripped",
+			str:  "1.2.3+build.5",
+			want: "1.2.3 1.2 1",
+		},
+		{
+			name: "pre-release kept build stripped",
+			str:  "1.2.3-rc.1+build.5",
+			opts: tagOptions{plan: semver.TagPlan{PreRelease: semver.PreReleaseFloating}},
+			want: "1.2.3-rc.1 1.2-rc.1 1-rc.1",
+		},
+		{
+			name:    "invalid version",
+			str:     "-0.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tags(tt.str, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("tags() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tagsPublished(t *testing.T) {
+	published := semver.MustParseAll([]string{"1.1.8", "1.2.0", "1.3.0-rc.1", "2.0.0"})
+	tests := []struct {
+		name string
+		str  string
+		opts tagOptions
+		want string
+	}{
+		{
+			name: "hotfix of an older minor",
+			str:  "1.1.9",
+			opts: tagOptions{plan: semver.TagPlan{Published: published}},
+			want: "1.1.9 1.1",
+		},
+		{
+			name: "newest of its major",
+			str:  "1.2.1",
+			opts: tagOptions{plan: semver.TagPlan{Published: published, Channel: "latest"}},
+			want: "1.2.1 1.2 1",
+		},
+		{
+			name: "published pre-releases are ignored for releases",
+			str:  "1.2.2",
+			opts: tagOptions{plan: semver.TagPlan{Published: published}},
+			want: "1.2.2 1.2 1",
+		},
+		{
+			name: "republished version keeps floating tags",
+			str:  "2.0.0",
+			opts: tagOptions{plan: semver.TagPlan{Published: published, Channel: "latest"}},
+			want: "2.0.0 2.0 2 latest",
+		},
+		{
+			name: "older pre-release",
+			str:  "1.3.0-beta.1",
+			opts: tagOptions{plan: semver.TagPlan{Published: published}},
+			want: "1.3.0-beta.1",
+		},
+		{
+			name: "latest",
+			str:  "2.1.0",
+			opts: tagOptions{plan: semver.TagPlan{Published: published, Channel: "latest"}},
+			want: "2.1.0 2.1 2 latest",
+		},
+		{
+			name: "build metadata",
+			str:  "1.2.3-rc.1+git.a1b2c3",
+			opts: tagOptions{plan: semver.TagPlan{Build: true, PreRelease: semver.PreReleaseChannelFloating}},
+			want: "1.2.3-rc.1_git.a1b2c3 1.2.3-rc.1 1.2-rc 1-rc rc",
+		},
+		{
+			name: "build metadata dropped",
+			str:  "1.2.3+git.a1b2c3",
+			opts: tagOptions{},
+			want: "1.2.3 1.2 1",
+		},
+		{
+			name: "build without metadata",
+			str:  "1.2.3",
+			opts: tagOptions{plan: semver.TagPlan{Build: true}},
+			want: "1.2.3 1.2 1",
+		},
+		{
+			name: "no latest for pre-releases",
+			str:  "3.0.0-rc.1",
+			opts: tagOptions{plan: semver.TagPlan{Channel: "latest"}},
+			want: "3.0.0-rc.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tags(tt.str, tt.opts)
+			if err != nil {
+				t.Errorf("tags() error = %v", err)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("tags() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readPublished(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "published")
+	if err := os.WriteFile(path, []byte("1.0.0\n\n1.1.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	vers, err := readPublished([]string{"0.1.0,0.2.0", "0.3.0"}, path, nil)
+	if err != nil {
+		t.Fatalf("readPublished() error = %v", err)
+	}
+	if len(vers) != 5 || vers[4].String() != "1.1.0" {
+		t.Errorf("readPublished() got = %v", vers)
+	}
+
+	vers, err = readPublished(nil, "-", strings.NewReader("2.0.0\n"))
+	if err != nil || len(vers) != 1 {
+		t.Errorf("readPublished() got = %v, error = %v", vers, err)
+	}
+
+	if _, err := readPublished([]string{"1.0"}, "", nil); err == nil {
+		t.Error("readPublished() should fail for invalid versions")
+	}
+}
+
+func Test_tagsFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		separator string
+		format    string
+		want      string
+	}{
+		{name: "separator", separator: ",", want: "v1.2.3-alpine,v1.2-alpine,v1-alpine,alpine"},
+		{name: "newline separator", separator: separatorReplacer.Replace(`\n`), want: "v1.2.3-alpine\nv1.2-alpine\nv1-alpine\nalpine"},
+		{name: "format", format: "ghcr.io/nothub/app:{{.Name}}", want: "ghcr.io/nothub/app:v1.2.3-alpine ghcr.io/nothub/app:v1.2-alpine ghcr.io/nothub/app:v1-alpine ghcr.io/nothub/app:alpine"},
+		{name: "format fields", separator: ";", format: "{{.Kind}}={{.Name}}@{{.Version.Major}}", want: "exact=v1.2.3-alpine@1;minor=v1.2-alpine@1;major=v1-alpine@1;channel=alpine@1"},
+		{name: "format version", format: "{{.Name}}={{.Version}}", want: "v1.2.3-alpine=1.2.3 v1.2-alpine=1.2.3 v1-alpine=1.2.3 alpine=1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tagOptions{
+				plan:      semver.TagPlan{Prefix: "v", Variant: "alpine", Channel: "latest"},
+				separator: tt.separator,
+			}
+			if tt.format != "" {
+				var err error
+				if opts.format, err = parseFormat(tt.format); err != nil {
+					t.Fatalf("parseFormat() error = %v", err)
+				}
+			}
+			got, err := tags("1.2.3", opts)
+			if err != nil {
+				t.Errorf("tags() error = %v", err)
+				return
+			}
+			if got.text != tt.want {
+				t.Errorf("tags() got = %v, want %v", got.text, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseFormat("{{.Name"); !errors.Is(err, semver.ErrTemplate) {
+		t.Errorf("parseFormat() error = %v, wantErr %v", err, semver.ErrTemplate)
+	}
+	format, _ := parseFormat("{{.Unknown}}")
+	if _, err := tags("1.2.3", tagOptions{format: format}); !errors.Is(err, semver.ErrTemplate) {
+		t.Errorf("tags() error = %v, wantErr %v", err, semver.ErrTemplate)
+	}
+}
@@ -0,0 +1,338 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a set of version ranges, following the range syntax of npm.
+// A Version satisfies a Constraint if it satisfies any of its ranges.
+//
+//	>=1.2.3 <2.0.0        both comparators (also separated by ",")
+//	1.2.3 - 2.3.4         hyphen range, inclusive
+//	1.2.x, 1.2, 1, *      x-ranges
+//	~1.2.3                >=1.2.3 <1.3.0-0
+//	^1.2.3                >=1.2.3 <2.0.0-0
+//	^0.2.3                >=0.2.3 <0.3.0-0
+//	!=1.2.3               any version except 1.2.3
+//	^1.0.0 || ^2.0.0      either range
+//
+// Versions with pre-release metadata only satisfy a range if one of its
+// comparators names a pre-release of the same major, minor and patch version.
+type Constraint struct {
+	str    string
+	ranges [][]comparator
+}
+
+type comparator struct {
+	op  string
+	ver Version
+	// bound added while desugaring, e.g. the upper bound of ^1.2.3
+	implied bool
+}
+
+var ErrConstraint = errors.New("invalid constraint")
+
+var operators = []string{"~>", ">=", "<=", "!=", "==", ">", "<", "=", "~", "^"}
+
+var partialRegex = regexp.MustCompile("^v?(0|[1-9]\\d*|[xX*])(?:\\.(0|[1-9]\\d*|[xX*]))?(?:\\.(0|[1-9]\\d*|[xX*]))?$")
+
+// ParseConstraint will attempt to convert a string to a semver.Constraint.
+//
+// ParseConstraint might return semver.ErrConstraint.
+func ParseConstraint(str string) (*Constraint, error) {
+	c := &Constraint{str: strings.TrimSpace(str)}
+	for _, rng := range strings.Split(str, "||") {
+		comps, err := parseRange(rng)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrConstraint, str, err)
+		}
+		c.ranges = append(c.ranges, comps)
+	}
+	return c, nil
+}
+
+// MustParseConstraint wraps ParseConstraint and panics on error.
+func MustParseConstraint(str string) *Constraint {
+	c, err := ParseConstraint(str)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Check returns true if Version satisfies the Constraint.
+func (c *Constraint) Check(v Version) bool {
+	for _, comps := range c.ranges {
+		if checkRange(comps, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the string the Constraint was parsed from.
+func (c *Constraint) String() string {
+	return c.str
+}
+
+// Filter returns all versions satisfying the Constraint, in their original order.
+func Filter(vers []Version, c *Constraint) []Version {
+	var res []Version
+	for _, v := range vers {
+		if c.Check(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// MaxSatisfying returns the newest Version satisfying the Constraint.
+func MaxSatisfying(vers []Version, c *Constraint) (res Version, ok bool) {
+	for _, v := range vers {
+		if c.Check(v) && (!ok || v.Newer(res)) {
+			res = v
+			ok = true
+		}
+	}
+	return res, ok
+}
+
+// MinSatisfying returns the oldest Version satisfying the Constraint.
+func MinSatisfying(vers []Version, c *Constraint) (res Version, ok bool) {
+	for _, v := range vers {
+		if c.Check(v) && (!ok || v.Older(res)) {
+			res = v
+			ok = true
+		}
+	}
+	return res, ok
+}
+
+func checkRange(comps []comparator, v Version) bool {
+	for _, comp := range comps {
+		if !comp.check(v) {
+			return false
+		}
+	}
+	if v.IsRelease() {
+		return true
+	}
+	// pre-releases need to be opted in explicitly
+	for _, comp := range comps {
+		if comp.implied || comp.ver.IsRelease() {
+			continue
+		}
+		if comp.ver.Major == v.Major && comp.ver.Minor == v.Minor && comp.ver.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func (comp *comparator) check(v Version) bool {
+	result := Compare(v, comp.ver)
+	switch comp.op {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return false
+}
+
+func parseRange(str string) ([]comparator, error) {
+	tokens := strings.Fields(strings.ReplaceAll(str, ",", " "))
+
+	// operators separated from their version by whitespace
+	for i := 0; i < len(tokens)-1; i++ {
+		for _, op := range operators {
+			if tokens[i] == op {
+				tokens[i] += tokens[i+1]
+				tokens = append(tokens[:i+1], tokens[i+2:]...)
+				break
+			}
+		}
+	}
+
+	comps := []comparator{}
+	for i := 0; i < len(tokens); i++ {
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			res, err := hyphenRange(tokens[i], tokens[i+2])
+			if err != nil {
+				return nil, err
+			}
+			comps = append(comps, res...)
+			i += 2
+			continue
+		}
+		res, err := parseComparator(tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		comps = append(comps, res...)
+	}
+	return comps, nil
+}
+
+// partial is a version with optional minor and patch, like 1.2 or 1.x.
+type partial struct {
+	// count of specified version core fields, 0 for *
+	n   int
+	ver Version
+}
+
+func parsePartial(str string) (partial, error) {
+	if ver, err := Parse(strings.TrimPrefix(str, "v")); err == nil {
+		return partial{n: 3, ver: ver}, nil
+	}
+	m := partialRegex.FindStringSubmatch(str)
+	if m == nil {
+		return partial{}, fmt.Errorf("invalid version %q", str)
+	}
+	var p partial
+	fields := []*int{&p.ver.Major, &p.ver.Minor, &p.ver.Patch}
+	for i, s := range m[1:] {
+		if s == "" || s == "x" || s == "X" || s == "*" {
+			break
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return partial{}, err
+		}
+		*fields[i] = n
+		p.n++
+	}
+	return p, nil
+}
+
+// upper returns the exclusive upper bound of a partial version.
+func (p partial) upper() Version {
+	if p.n == 1 {
+		return Version{Major: p.ver.Major + 1, PreRelease: []string{"0"}}
+	}
+	return Version{Major: p.ver.Major, Minor: p.ver.Minor + 1, PreRelease: []string{"0"}}
+}
+
+// anything matches all releases, nothing matches no version at all.
+var anything = []comparator{}
+var nothing = []comparator{{op: "<", ver: Version{PreRelease: []string{"0"}}, implied: true}}
+
+func parseComparator(str string) ([]comparator, error) {
+	op := ""
+	for _, o := range operators {
+		if strings.HasPrefix(str, o) {
+			op = o
+			break
+		}
+	}
+	if op == "==" {
+		op = "="
+	}
+	p, err := parsePartial(strings.TrimPrefix(str, op))
+	if err != nil {
+		return nil, err
+	}
+	v := p.ver
+
+	if p.n == 3 {
+		switch op {
+		case "", "=":
+			return []comparator{{op: "=", ver: v}}, nil
+		case "!=", ">", ">=", "<", "<=":
+			return []comparator{{op: op, ver: v}}, nil
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if p.n == 0 {
+			return anything, nil
+		}
+		return []comparator{{op: ">=", ver: v}, {op: "<", ver: p.upper(), implied: true}}, nil
+	case "!=":
+		return nil, fmt.Errorf("!= requires a full version, got %q", str)
+	case ">":
+		switch p.n {
+		case 0:
+			return nothing, nil
+		case 1:
+			return []comparator{{op: ">=", ver: Version{Major: v.Major + 1}}}, nil
+		default:
+			return []comparator{{op: ">=", ver: Version{Major: v.Major, Minor: v.Minor + 1}}}, nil
+		}
+	case ">=":
+		if p.n == 0 {
+			return anything, nil
+		}
+		return []comparator{{op: ">=", ver: v}}, nil
+	case "<":
+		if p.n == 0 {
+			return nothing, nil
+		}
+		return []comparator{{op: "<", ver: Version{Major: v.Major, Minor: v.Minor, PreRelease: []string{"0"}}, implied: true}}, nil
+	case "<=":
+		if p.n == 0 {
+			return anything, nil
+		}
+		return []comparator{{op: "<", ver: p.upper(), implied: true}}, nil
+	case "~", "~>":
+		if p.n == 0 {
+			return anything, nil
+		}
+		upper := Version{Major: v.Major, Minor: v.Minor + 1, PreRelease: []string{"0"}}
+		if p.n == 1 {
+			upper = Version{Major: v.Major + 1, PreRelease: []string{"0"}}
+		}
+		return []comparator{{op: ">=", ver: v}, {op: "<", ver: upper, implied: true}}, nil
+	case "^":
+		if p.n == 0 {
+			return anything, nil
+		}
+		var upper Version
+		switch {
+		case v.Major > 0 || p.n == 1:
+			upper = Version{Major: v.Major + 1}
+		case v.Minor > 0 || p.n == 2:
+			upper = Version{Minor: v.Minor + 1}
+		default:
+			upper = Version{Patch: v.Patch + 1}
+		}
+		upper.PreRelease = []string{"0"}
+		return []comparator{{op: ">=", ver: v}, {op: "<", ver: upper, implied: true}}, nil
+	}
+
+	return nil, fmt.Errorf("invalid comparator %q", str)
+}
+
+func hyphenRange(from string, to string) ([]comparator, error) {
+	lo, err := parsePartial(from)
+	if err != nil {
+		return nil, err
+	}
+	hi, err := parsePartial(to)
+	if err != nil {
+		return nil, err
+	}
+
+	comps := []comparator{{op: ">=", ver: lo.ver}}
+	switch hi.n {
+	case 0:
+	case 3:
+		comps = append(comps, comparator{op: "<=", ver: hi.ver})
+	default:
+		comps = append(comps, comparator{op: "<", ver: hi.upper(), implied: true})
+	}
+	return comps, nil
+}
@@ -0,0 +1,183 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		misses     []string
+	}{
+		{
+			constraint: "1.2.3",
+			matches:    []string{"1.2.3", "1.2.3+build"},
+			misses:     []string{"1.2.4", "1.2.3-rc.1"},
+		},
+		{
+			constraint: ">=1.2.3 <2.0.0",
+			matches:    []string{"1.2.3", "1.9.9"},
+			misses:     []string{"1.2.2", "2.0.0", "1.5.0-rc.1"},
+		},
+		{
+			constraint: ">= 1.2.3, < 2.0.0",
+			matches:    []string{"1.2.3", "1.9.9"},
+			misses:     []string{"1.2.2", "2.0.0"},
+		},
+		{
+			constraint: "1.2.3 - 2.3.4",
+			matches:    []string{"1.2.3", "2.3.4"},
+			misses:     []string{"1.2.2", "2.3.5"},
+		},
+		{
+			constraint: "1.2 - 2.3",
+			matches:    []string{"1.2.0", "2.3.99"},
+			misses:     []string{"1.1.9", "2.4.0"},
+		},
+		{
+			constraint: "1.2.x",
+			matches:    []string{"1.2.0", "1.2.99"},
+			misses:     []string{"1.3.0", "1.1.0", "1.2.5-rc.1"},
+		},
+		{
+			constraint: "1",
+			matches:    []string{"1.0.0", "1.99.0"},
+			misses:     []string{"2.0.0", "0.9.0", "2.0.0-rc.1"},
+		},
+		{
+			constraint: "*",
+			matches:    []string{"0.0.0", "99.0.0"},
+			misses:     []string{"1.0.0-rc.1"},
+		},
+		{
+			constraint: "~1.2.3",
+			matches:    []string{"1.2.3", "1.2.9"},
+			misses:     []string{"1.3.0", "1.2.2"},
+		},
+		{
+			constraint: "~1",
+			matches:    []string{"1.0.0", "1.9.0"},
+			misses:     []string{"2.0.0"},
+		},
+		{
+			constraint: "^1.2.3",
+			matches:    []string{"1.2.3", "1.9.0"},
+			misses:     []string{"2.0.0", "1.2.2", "2.0.0-rc.1"},
+		},
+		{
+			constraint: "^0.2.3",
+			matches:    []string{"0.2.3", "0.2.9"},
+			misses:     []string{"0.3.0", "0.2.2"},
+		},
+		{
+			constraint: "^0.0.3",
+			matches:    []string{"0.0.3"},
+			misses:     []string{"0.0.4", "0.0.2"},
+		},
+		{
+			constraint: "^0.0",
+			matches:    []string{"0.0.0", "0.0.9"},
+			misses:     []string{"0.1.0"},
+		},
+		{
+			constraint: "^1.2.3-beta.2",
+			matches:    []string{"1.2.3-beta.2", "1.2.3-beta.4", "1.2.3", "1.5.0"},
+			misses:     []string{"1.2.3-beta.1", "1.5.0-beta.3"},
+		},
+		{
+			constraint: ">1.2",
+			matches:    []string{"1.3.0"},
+			misses:     []string{"1.2.9"},
+		},
+		{
+			constraint: "<=1.2",
+			matches:    []string{"1.2.9", "0.1.0"},
+			misses:     []string{"1.3.0"},
+		},
+		{
+			constraint: "<1.2",
+			matches:    []string{"1.1.9"},
+			misses:     []string{"1.2.0"},
+		},
+		{
+			constraint: "!=1.2.3",
+			matches:    []string{"1.2.4", "1.2.2"},
+			misses:     []string{"1.2.3"},
+		},
+		{
+			constraint: "^1.0.0 || ^3.0.0",
+			matches:    []string{"1.5.0", "3.1.0"},
+			misses:     []string{"2.0.0", "4.0.0"},
+		},
+		{
+			constraint: "v1.2.3",
+			matches:    []string{"1.2.3"},
+			misses:     []string{"1.2.4"},
+		},
+		{
+			constraint: ">*",
+			misses:     []string{"0.0.0", "1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, v := range tt.matches {
+				if !c.Check(MustParse(v)) {
+					t.Errorf("%s should satisfy %s", v, tt.constraint)
+				}
+			}
+			for _, v := range tt.misses {
+				if c.Check(MustParse(v)) {
+					t.Errorf("%s should not satisfy %s", v, tt.constraint)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalids(t *testing.T) {
+	tests := []string{
+		"foo",
+		">=1.2.3 <",
+		"1.2.3.4",
+		"01.2.3",
+		"!=1.2",
+		"1.2.3 -",
+		"^1.x.3-rc",
+		"=>1.2.3",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseConstraint(test)
+			if !errors.Is(err, ErrConstraint) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSatisfying(t *testing.T) {
+	vers := MustParseAll([]string{"1.2.0", "1.10.0", "2.0.0", "1.9.0-rc.1", "1.3.5", "0.9.0"})
+	c := MustParseConstraint("^1.2.0")
+
+	filtered := Filter(vers, c)
+	if len(filtered) != 3 || filtered[0].String() != "1.2.0" || filtered[1].String() != "1.10.0" || filtered[2].String() != "1.3.5" {
+		t.Errorf("unexpected filtered versions: %v", filtered)
+	}
+
+	if v, ok := MaxSatisfying(vers, c); !ok || v.String() != "1.10.0" {
+		t.Errorf("unexpected max satisfying = %s, ok = %v", v.String(), ok)
+	}
+	if v, ok := MinSatisfying(vers, c); !ok || v.String() != "1.2.0" {
+		t.Errorf("unexpected min satisfying = %s, ok = %v", v.String(), ok)
+	}
+	if _, ok := MaxSatisfying(vers, MustParseConstraint(">=3")); ok {
+		t.Error("should not find a satisfying version")
+	}
+}
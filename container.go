@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ContainerTagMaxLength is the maximum length of a container image tag.
+const ContainerTagMaxLength = 128
+
+// ContainerTagBuildSeparator replaces the "+" in front of the build metadata in container tags.
+// Versions never contain "_", so the tag can be decoded back into the Version.
+const ContainerTagBuildSeparator = "_"
+
+var ErrContainerTag = errors.New("invalid container tag")
+
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pulling-manifests
+var containerTagRegex = regexp.MustCompile("^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$")
+
+// ContainerTag will attempt to render Version as container image tag.
+// Container tags can not contain "+", the build metadata is separated by
+// semver.ContainerTagBuildSeparator instead.
+//
+//	1.2.3-rc.1+git.a1b2c3 -> 1.2.3-rc.1_git.a1b2c3
+//
+// ContainerTag might return semver.ErrContainerTag if the tag exceeds
+// semver.ContainerTagMaxLength.
+func (v *Version) ContainerTag() (string, error) {
+	tag := strings.Replace(v.String(), "+", ContainerTagBuildSeparator, 1)
+	if err := checkContainerTag(tag); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// checkContainerTag validates the length and charset of a container tag.
+func checkContainerTag(tag string) error {
+	if len(tag) > ContainerTagMaxLength {
+		return fmt.Errorf("%w: %d characters exceed limit of %d: %q", ErrContainerTag, len(tag), ContainerTagMaxLength, tag)
+	}
+	if !containerTagRegex.MatchString(tag) {
+		return fmt.Errorf("%w: %q", ErrContainerTag, tag)
+	}
+	return nil
+}
+
+// ParseContainerTag will attempt to decode a container image tag rendered by
+// Version.ContainerTag back into a semver.Version.
+//
+// ParseContainerTag might return semver.ErrContainerTag, semver.ErrInvalid,
+// strconv.ErrRange or strconv.ErrSyntax.
+func ParseContainerTag(str string) (Version, error) {
+	if !containerTagRegex.MatchString(str) {
+		return Version{}, fmt.Errorf("%w: %q", ErrContainerTag, str)
+	}
+	return Parse(strings.Replace(str, ContainerTagBuildSeparator, "+", 1))
+}
+
+// MustParseContainerTag wraps ParseContainerTag and panics on error.
+func MustParseContainerTag(str string) Version {
+	ver, err := ParseContainerTag(str)
+	if err != nil {
+		panic(err)
+	}
+	return ver
+}
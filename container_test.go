@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVersion_ContainerTag(t *testing.T) {
+	tests := []struct {
+		version string
+		tag     string
+	}{
+		{version: "1.2.3", tag: "1.2.3"},
+		{version: "1.2.3-rc.1", tag: "1.2.3-rc.1"},
+		{version: "1.2.3+git.a1b2c3", tag: "1.2.3_git.a1b2c3"},
+		{version: "1.2.3-rc.1+git.a1b2c3", tag: "1.2.3-rc.1_git.a1b2c3"},
+		{version: "1.2.3-x-y+build-1", tag: "1.2.3-x-y_build-1"},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			ver := MustParse(test.version)
+			tag, err := ver.ContainerTag()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tag != test.tag {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.tag, tag)
+			}
+			decoded, err := ParseContainerTag(tag)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if decoded.String() != test.version {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.version, decoded.String())
+			}
+		})
+	}
+}
+
+func TestVersion_ContainerTagTooLong(t *testing.T) {
+	ver := MustParse("1.2.3+" + strings.Repeat("a", ContainerTagMaxLength))
+	_, err := ver.ContainerTag()
+	if !errors.Is(err, ErrContainerTag) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
+
+func TestParseContainerTagInvalids(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{input: "", err: ErrContainerTag},
+		{input: "1.2.3+build", err: ErrContainerTag},
+		{input: ".1.2.3", err: ErrContainerTag},
+		{input: "1.2.3_" + strings.Repeat("a", ContainerTagMaxLength), err: ErrContainerTag},
+		{input: "latest", err: ErrInvalid},
+		{input: "1.2", err: ErrInvalid},
+		{input: "1.2.3_a_b", err: ErrInvalid},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := ParseContainerTag(test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package conventional
+
+import (
+	"github.com/nothub/semver"
+)
+
+// Bump is the kind of version increment a change requires.
+type Bump int
+
+const (
+	None Bump = iota
+	Patch
+	Minor
+	Major
+)
+
+func (b Bump) String() string {
+	switch b {
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Bump returns the increment the commit requires on its own:
+// breaking changes require a major, features a minor and
+// fixes and performance improvements a patch release.
+func (c *Commit) Bump() Bump {
+	switch {
+	case c.Breaking:
+		return Major
+	case c.Type == "feat":
+		return Minor
+	case c.Type == "fix" || c.Type == "perf":
+		return Patch
+	default:
+		return None
+	}
+}
+
+// Next returns the version following ver for a set of commits, the applied
+// increment and the commits that drove the decision.
+//
+// While the major version is 0, every increment is lowered by one level
+// (breaking changes bump the minor, features the patch version), as 0.x
+// versions make no compatibility promises.
+//
+// If ver is a pre-release, the release of the same version core is
+// returned if it satisfies the increment:
+//
+//	1.0.0-rc.1 + fix   -> 1.0.0
+//	1.2.0-rc.1 + feat  -> 1.2.0
+//	1.2.1-rc.1 + feat  -> 1.3.0
+func Next(ver semver.Version, commits []Commit) (semver.Version, Bump, []Commit) {
+	bump := None
+	var drivers []Commit
+	for _, c := range commits {
+		b := c.Bump()
+		if b > bump {
+			bump = b
+			drivers = nil
+		}
+		if b == bump && b != None {
+			drivers = append(drivers, c)
+		}
+	}
+
+	if ver.Major == 0 && bump > Patch {
+		bump--
+	}
+
+	return Apply(ver, bump), bump, drivers
+}
+
+// Apply increments ver as required by bump.
+func Apply(ver semver.Version, bump Bump) semver.Version {
+	if bump == None {
+		return ver
+	}
+
+	if !ver.IsRelease() {
+		core := semver.Version{Major: ver.Major, Minor: ver.Minor, Patch: ver.Patch}
+		switch {
+		case bump == Patch,
+			bump == Minor && ver.Patch == 0,
+			bump == Major && ver.Minor == 0 && ver.Patch == 0:
+			return core
+		}
+	}
+
+	switch bump {
+	case Major:
+		return ver.NextMajor()
+	case Minor:
+		return ver.NextMinor()
+	default:
+		return ver.NextPatch()
+	}
+}
@@ -0,0 +1,104 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		messages []string
+		expected string
+		bump     Bump
+		drivers  int
+	}{
+		{
+			name:     "fix",
+			version:  "1.2.3",
+			messages: []string{"fix: a", "docs: b", "chore: c"},
+			expected: "1.2.4",
+			bump:     Patch,
+			drivers:  1,
+		},
+		{
+			name:     "feature",
+			version:  "1.2.3",
+			messages: []string{"fix: a", "feat: b", "feat(x): c"},
+			expected: "1.3.0",
+			bump:     Minor,
+			drivers:  2,
+		},
+		{
+			name:     "breaking",
+			version:  "1.2.3",
+			messages: []string{"feat: a", "fix!: b"},
+			expected: "2.0.0",
+			bump:     Major,
+			drivers:  1,
+		},
+		{
+			name:     "nothing relevant",
+			version:  "1.2.3",
+			messages: []string{"docs: a", "ci: b"},
+			expected: "1.2.3",
+			bump:     None,
+		},
+		{
+			name:     "breaking in 0.x",
+			version:  "0.4.1",
+			messages: []string{"feat!: a"},
+			expected: "0.5.0",
+			bump:     Minor,
+			drivers:  1,
+		},
+		{
+			name:     "feature in 0.x",
+			version:  "0.4.1",
+			messages: []string{"feat: a"},
+			expected: "0.4.2",
+			bump:     Patch,
+			drivers:  1,
+		},
+		{
+			name:     "fix on pre-release",
+			version:  "1.0.0-rc.1",
+			messages: []string{"fix: a"},
+			expected: "1.0.0",
+			bump:     Patch,
+			drivers:  1,
+		},
+		{
+			name:     "feature on pre-release patch",
+			version:  "1.2.1-rc.1",
+			messages: []string{"feat: a"},
+			expected: "1.3.0",
+			bump:     Minor,
+			drivers:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commits []Commit
+			for _, msg := range tt.messages {
+				c, err := Parse(msg)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				commits = append(commits, c)
+			}
+			ver, bump, drivers := Next(semver.MustParse(tt.version), commits)
+			if ver.String() != tt.expected {
+				t.Errorf("unexpected version:\nexpected = %s\nactual   = %s", tt.expected, ver.String())
+			}
+			if bump != tt.bump {
+				t.Errorf("unexpected bump:\nexpected = %s\nactual   = %s", tt.bump, bump)
+			}
+			if len(drivers) != tt.drivers {
+				t.Errorf("unexpected drivers:\nexpected = %d\nactual   = %d", tt.drivers, len(drivers))
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package conventional parses commit messages following the Conventional Commits
+// specification (https://www.conventionalcommits.org/) and derives the next
+// semantic version from them.
+package conventional
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Commit is a parsed conventional commit message.
+//
+//	feat(api)!: drop legacy endpoints
+//
+//	Body paragraphs.
+//
+//	BREAKING CHANGE: /v1 is gone
+//	Refs: #123
+type Commit struct {
+	// lower case type, e.g. "feat" or "fix"
+	Type  string
+	Scope string
+	// marked by "!" in the header or a BREAKING CHANGE footer
+	Breaking    bool
+	Description string
+	Body        string
+	Footers     []Footer
+}
+
+// Footer is a git trailer like footer of a commit message.
+type Footer struct {
+	Token string
+	Value string
+}
+
+var ErrInvalid = errors.New("not a conventional commit")
+
+var headerRegex = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9-]*)(?:\(([^()\r\n]+)\))?(!)?: +(\S.*)$`)
+var footerRegex = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[a-zA-Z][a-zA-Z0-9-]*)(?:: | #)(.*)$`)
+
+// Parse will attempt to convert a commit message to a conventional.Commit struct.
+//
+// Parse might return conventional.ErrInvalid.
+func Parse(msg string) (Commit, error) {
+	msg = strings.ReplaceAll(strings.TrimSpace(msg), "\r\n", "\n")
+	header, rest, _ := strings.Cut(msg, "\n")
+
+	m := headerRegex.FindStringSubmatch(strings.TrimSpace(header))
+	if m == nil {
+		return Commit{}, ErrInvalid
+	}
+	c := Commit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Breaking:    m[3] == "!",
+		Description: strings.TrimSpace(m[4]),
+	}
+
+	paragraphs := splitParagraphs(rest)
+	if n := len(paragraphs); n > 0 && isFooters(paragraphs[n-1]) {
+		c.Footers = parseFooters(paragraphs[n-1])
+		paragraphs = paragraphs[:n-1]
+	}
+	c.Body = strings.Join(paragraphs, "\n\n")
+
+	for _, f := range c.Footers {
+		if f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE" {
+			c.Breaking = true
+		}
+	}
+
+	return c, nil
+}
+
+// Header returns the first line of the commit message.
+func (c *Commit) Header() string {
+	sb := strings.Builder{}
+	sb.WriteString(c.Type)
+	if c.Scope != "" {
+		sb.WriteString("(")
+		sb.WriteString(c.Scope)
+		sb.WriteString(")")
+	}
+	if c.Breaking {
+		sb.WriteString("!")
+	}
+	sb.WriteString(": ")
+	sb.WriteString(c.Description)
+	return sb.String()
+}
+
+func splitParagraphs(str string) []string {
+	var paragraphs []string
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(str, "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	flush()
+	return paragraphs
+}
+
+func isFooters(paragraph string) bool {
+	first, _, _ := strings.Cut(paragraph, "\n")
+	return footerRegex.MatchString(first)
+}
+
+func parseFooters(paragraph string) []Footer {
+	var footers []Footer
+	for _, line := range strings.Split(paragraph, "\n") {
+		m := footerRegex.FindStringSubmatch(line)
+		if m == nil {
+			// continuation of a multi-line footer value
+			last := &footers[len(footers)-1]
+			last.Value += "\n" + line
+			continue
+		}
+		footers = append(footers, Footer{Token: m[1], Value: m[2]})
+	}
+	return footers
+}
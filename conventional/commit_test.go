@@ -0,0 +1,108 @@
+package conventional
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Commit
+	}{
+		{
+			input: "feat: add tags command",
+			expected: Commit{
+				Type:        "feat",
+				Description: "add tags command",
+			},
+		},
+		{
+			input: "fix(parser): reject leading zeros",
+			expected: Commit{
+				Type:        "fix",
+				Scope:       "parser",
+				Description: "reject leading zeros",
+			},
+		},
+		{
+			input: "refactor(api)!: drop legacy endpoints",
+			expected: Commit{
+				Type:        "refactor",
+				Scope:       "api",
+				Breaking:    true,
+				Description: "drop legacy endpoints",
+			},
+		},
+		{
+			input: "Feat: mixed case type",
+			expected: Commit{
+				Type:        "feat",
+				Description: "mixed case type",
+			},
+		},
+		{
+			input: "feat: allow config\n\nThe config file is now read\nfrom the working directory.\n\nSecond paragraph.\n\nBREAKING CHANGE: the --config flag\n  was removed\nRefs: #123",
+			expected: Commit{
+				Type:        "feat",
+				Breaking:    true,
+				Description: "allow config",
+				Body:        "The config file is now read\nfrom the working directory.\n\nSecond paragraph.",
+				Footers: []Footer{
+					{Token: "BREAKING CHANGE", Value: "the --config flag\n  was removed"},
+					{Token: "Refs", Value: "#123"},
+				},
+			},
+		},
+		{
+			input: "fix: typo\n\nCloses #42",
+			expected: Commit{
+				Type:        "fix",
+				Description: "typo",
+				Footers:     []Footer{{Token: "Closes", Value: "42"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(tt.expected, c) {
+				t.Errorf("unexpected result:\nexpected = %+v\nactual   = %+v", tt.expected, c)
+			}
+		})
+	}
+}
+
+func TestParseInvalids(t *testing.T) {
+	tests := []string{
+		"",
+		"add tags command",
+		"feat add tags command",
+		"feat:",
+		"feat():  missing scope",
+		"(api): missing type",
+		"Merge branch 'main' into feature",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := Parse(test)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCommit_Header(t *testing.T) {
+	c, err := Parse("feat(api): new endpoint\n\nBREAKING CHANGE: removed old one")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.Header() != "feat(api)!: new endpoint" {
+		t.Errorf("unexpected header: %s", c.Header())
+	}
+}
@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Describe is the parsed output of `git describe --tags --long`,
+// e.g. v1.2.3-14-gabc1234-dirty.
+type Describe struct {
+	// most recent tag reachable from the described commit
+	Tag TaggedVersion
+	// number of commits on top of Tag
+	Distance int
+	// abbreviated commit hash, without the "g" prefix
+	Hash string
+	// working tree has local modifications
+	Dirty bool
+}
+
+// DescribeTemplate is the default template used by Describe.Version.
+//
+// The template is executed with the following fields:
+//
+//	.Major .Minor .Patch  next version core (patch is only bumped for release tags)
+//	.Pre                  pre-release of the tag, dot separated
+//	.Distance             number of commits on top of the tag
+//	.Hash                 abbreviated commit hash
+//	.Dirty                working tree has local modifications
+const DescribeTemplate = "{{.Major}}.{{.Minor}}.{{.Patch}}-{{with .Pre}}{{.}}.{{end}}dev.{{.Distance}}+g{{.Hash}}{{if .Dirty}}.dirty{{end}}"
+
+var describeRegex = regexp.MustCompile("^(.+)-(0|[1-9]\\d*)-g([0-9a-f]{4,})$")
+
+// ParseDescribe will attempt to convert the output of git describe to a semver.Describe struct.
+// Output of an exactly tagged commit without --long is accepted too.
+//
+// ParseDescribe might return semver.ErrInvalid, strconv.ErrRange or strconv.ErrSyntax.
+func ParseDescribe(str string) (Describe, error) {
+	var d Describe
+
+	str, d.Dirty = strings.CutSuffix(strings.TrimSpace(str), "-dirty")
+
+	m := describeRegex.FindStringSubmatch(str)
+	if m == nil {
+		tag, err := ParseTagged(str)
+		if err != nil {
+			return Describe{}, err
+		}
+		d.Tag = tag
+		return d, nil
+	}
+
+	var err error
+	d.Tag, err = ParseTagged(m[1])
+	if err != nil {
+		return Describe{}, err
+	}
+	d.Distance, err = strconv.Atoi(m[2])
+	if err != nil {
+		return Describe{}, err
+	}
+	d.Hash = m[3]
+
+	return d, nil
+}
+
+// Version builds a development version from Describe using a text/template.
+// An empty template string selects DescribeTemplate.
+//
+// Exactly tagged, clean commits return the tag version itself. For all other
+// commits the default template produces versions that are newer than the tag
+// and older than the next release under Compare:
+//
+//	v1.2.3-14-gabc1234-dirty -> 1.2.4-dev.14+gabc1234.dirty
+//	v1.2.3-rc.1-2-gabc1234   -> 1.2.3-rc.1.dev.2+gabc1234
+//
+// Version might return semver.ErrInvalid if the template renders an invalid version.
+func (d *Describe) Version(tmpl string) (Version, error) {
+	if d.Distance == 0 && !d.Dirty {
+		return d.Tag.Version, nil
+	}
+
+	if tmpl == "" {
+		tmpl = DescribeTemplate
+	}
+	t, err := template.New("describe").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return Version{}, err
+	}
+
+	tag := d.Tag.Version
+	next := tag
+	if tag.IsRelease() {
+		next = tag.NextPatch()
+	}
+	data := struct {
+		Major    int
+		Minor    int
+		Patch    int
+		Pre      string
+		Distance int
+		Hash     string
+		Dirty    bool
+	}{
+		Major:    next.Major,
+		Minor:    next.Minor,
+		Patch:    next.Patch,
+		Pre:      strings.Join(tag.PreRelease, "."),
+		Distance: d.Distance,
+		Hash:     d.Hash,
+		Dirty:    d.Dirty,
+	}
+
+	sb := strings.Builder{}
+	if err := t.Execute(&sb, data); err != nil {
+		return Version{}, err
+	}
+	return Parse(sb.String())
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDescribe(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Describe
+	}{
+		{
+			input: "v1.2.3-14-gabc1234",
+			expected: Describe{
+				Tag:      TaggedVersion{Prefix: "v", Version: MustParse("1.2.3")},
+				Distance: 14,
+				Hash:     "abc1234",
+			},
+		},
+		{
+			input: "v1.2.3-14-gabc1234-dirty",
+			expected: Describe{
+				Tag:      TaggedVersion{Prefix: "v", Version: MustParse("1.2.3")},
+				Distance: 14,
+				Hash:     "abc1234",
+				Dirty:    true,
+			},
+		},
+		{
+			input: "api/v1.0.0-rc.1-0-g0123abcd",
+			expected: Describe{
+				Tag:  TaggedVersion{Prefix: "api/v", Version: MustParse("1.0.0-rc.1")},
+				Hash: "0123abcd",
+			},
+		},
+		{
+			input: "v2.0.0",
+			expected: Describe{
+				Tag: TaggedVersion{Prefix: "v", Version: MustParse("2.0.0")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDescribe(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if d.Tag.String() != tt.expected.Tag.String() || d.Tag.Prefix != tt.expected.Tag.Prefix ||
+				d.Distance != tt.expected.Distance || d.Hash != tt.expected.Hash || d.Dirty != tt.expected.Dirty {
+				t.Errorf("unexpected result:\nexpected = %+v\nactual   = %+v", tt.expected, d)
+			}
+		})
+	}
+}
+
+func TestParseDescribeInvalids(t *testing.T) {
+	tests := []string{
+		"",
+		"abc1234",
+		"v1.2-14-gabc1234",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseDescribe(test)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDescribe_Version(t *testing.T) {
+	tests := []struct {
+		input    string
+		template string
+		expected string
+	}{
+		{input: "v1.2.3-14-gabc1234-dirty", expected: "1.2.4-dev.14+gabc1234.dirty"},
+		{input: "v1.2.3-14-gabc1234", expected: "1.2.4-dev.14+gabc1234"},
+		{input: "v1.2.3-0-gabc1234", expected: "1.2.3"},
+		{input: "v1.2.3", expected: "1.2.3"},
+		{input: "v1.2.3-rc.1-2-gabc1234", expected: "1.2.3-rc.1.dev.2+gabc1234"},
+		{
+			input:    "v1.2.3-14-gabc1234",
+			template: "{{.Major}}.{{.Minor}}.{{.Patch}}-snapshot.{{.Distance}}",
+			expected: "1.2.4-snapshot.14",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDescribe(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			ver, err := d.Version(tt.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ver.String() != tt.expected {
+				t.Errorf("unexpected version:\nexpected = %s\nactual   = %s", tt.expected, ver.String())
+			}
+			if !ver.Newer(d.Tag.Version) && !ver.Same(d.Tag.Version) {
+				t.Errorf("%s should not be older than %s", ver.String(), d.Tag.Version.String())
+			}
+			next := d.Tag.Version.NextPatch()
+			if !ver.Older(next) && d.Tag.Version.IsRelease() {
+				t.Errorf("%s should be older than %s", ver.String(), next.String())
+			}
+		})
+	}
+}
+
+func TestDescribe_VersionInvalidTemplate(t *testing.T) {
+	d, err := ParseDescribe("v1.2.3-14-gabc1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = d.Version("{{.Major}}.{{.Minor}}")
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is a parsed commit object.
+type Commit struct {
+	Hash    string
+	Parents []string
+	// committer date
+	Time time.Time
+	// full commit message, subject and body
+	Message string
+}
+
+// Commit returns the commit a revision points to, annotated tags are peeled.
+//
+// Commit might return git.ErrNotFound.
+func (r *Repo) Commit(rev string) (Commit, error) {
+	hash, err := r.Resolve(rev)
+	if err != nil {
+		return Commit{}, err
+	}
+	hash, err = r.Peel(hash)
+	if err != nil {
+		return Commit{}, err
+	}
+	return r.readCommit(hash)
+}
+
+// Peel follows annotated tags until a non-tag object is reached.
+func (r *Repo) Peel(hash string) (string, error) {
+	for i := 0; i < 10; i++ {
+		kind, data, err := r.readObject(hash)
+		if err != nil {
+			return "", err
+		}
+		if kind != "tag" {
+			return hash, nil
+		}
+		headers, _ := parseHeaders(data)
+		hash = headers["object"]
+		if !isHash(hash) {
+			return "", fmt.Errorf("malformed tag object")
+		}
+	}
+	return "", fmt.Errorf("tag chain too deep")
+}
+
+func (r *Repo) readCommit(hash string) (Commit, error) {
+	kind, data, err := r.readObject(hash)
+	if err != nil {
+		return Commit{}, err
+	}
+	if kind != "commit" {
+		return Commit{}, fmt.Errorf("object %s is a %s, not a commit", hash, kind)
+	}
+
+	c := Commit{Hash: hash}
+	headers, msg := parseHeaders(data)
+	c.Message = msg
+	for _, line := range strings.Split(string(data[:len(data)-len(msg)]), "\n") {
+		if parent, ok := strings.CutPrefix(line, "parent "); ok {
+			c.Parents = append(c.Parents, parent)
+		}
+	}
+	// committer name <email> timestamp timezone
+	fields := strings.Fields(headers["committer"])
+	if len(fields) >= 2 {
+		if sec, err := strconv.ParseInt(fields[len(fields)-2], 10, 64); err == nil {
+			c.Time = time.Unix(sec, 0).UTC()
+		}
+	}
+
+	return c, nil
+}
+
+// Ancestors returns the set of commits reachable from a revision, including itself.
+func (r *Repo) Ancestors(rev string) (map[string]bool, error) {
+	start, err := r.Commit(rev)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{start.Hash: true}
+	queue := []string{start.Hash}
+	for len(queue) > 0 {
+		c, err := r.readCommit(queue[0])
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		for _, parent := range c.Parents {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return seen, nil
+}
+
+// Log returns the commits reachable from to but not from from, newest first.
+// An empty from lists the full history of to.
+func (r *Repo) Log(from string, to string) ([]Commit, error) {
+	exclude := map[string]bool{}
+	if from != "" {
+		var err error
+		exclude, err = r.Ancestors(from)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start, err := r.Commit(to)
+	if err != nil {
+		return nil, err
+	}
+	if exclude[start.Hash] {
+		return nil, nil
+	}
+
+	var commits []Commit
+	seen := map[string]bool{start.Hash: true}
+	queue := []Commit{start}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		commits = append(commits, c)
+		for _, parent := range c.Parents {
+			if seen[parent] || exclude[parent] {
+				continue
+			}
+			seen[parent] = true
+			pc, err := r.readCommit(parent)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, pc)
+		}
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Time.After(commits[j].Time)
+	})
+	return commits, nil
+}
+
+// parseHeaders splits a commit or tag object into headers and message.
+// Continuation lines of multi-line headers (like signatures) are skipped.
+func parseHeaders(data []byte) (map[string]string, string) {
+	headers := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	consumed := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		consumed += len(line) + 1
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, " ") {
+			continue
+		}
+		key, val, _ := strings.Cut(line, " ")
+		if _, ok := headers[key]; !ok {
+			headers[key] = val
+		}
+	}
+	if consumed > len(data) {
+		consumed = len(data)
+	}
+	return headers, string(data[consumed:])
+}
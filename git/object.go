@@ -0,0 +1,400 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	objCommit   = 1
+	objTree     = 2
+	objBlob     = 3
+	objTag      = 4
+	objOfsDelta = 6
+	objRefDelta = 7
+)
+
+var objNames = map[int]string{
+	objCommit: "commit",
+	objTree:   "tree",
+	objBlob:   "blob",
+	objTag:    "tag",
+}
+
+// readObject returns type and content of an object,
+// looking at loose objects first and packfiles second.
+func (r *Repo) readObject(hash string) (string, []byte, error) {
+	kind, data, err := r.readLoose(hash)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return kind, data, err
+	}
+
+	if err := r.loadPacks(); err != nil {
+		return "", nil, err
+	}
+	raw, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, p := range r.packs {
+		offset, ok := p.find(raw)
+		if !ok {
+			continue
+		}
+		typ, data, err := p.read(r, offset)
+		if err != nil {
+			return "", nil, err
+		}
+		return objNames[typ], data, nil
+	}
+
+	return "", nil, fmt.Errorf("%w: object %s", ErrNotFound, hash)
+}
+
+// expandHash returns the full hash of the only object whose hash starts
+// with prefix, looking at loose objects and packfiles.
+func (r *Repo) expandHash(prefix string) (string, error) {
+	matches := make(map[string]bool)
+	entries, err := os.ReadDir(filepath.Join(r.commonDir, "objects", prefix[:2]))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	for _, e := range entries {
+		if hash := prefix[:2] + e.Name(); isHash(hash) && strings.HasPrefix(hash, prefix) {
+			matches[hash] = true
+		}
+	}
+
+	if err := r.loadPacks(); err != nil {
+		return "", err
+	}
+	for _, p := range r.packs {
+		for _, hash := range p.findPrefix(prefix) {
+			matches[hash] = true
+		}
+	}
+
+	if len(matches) > 1 {
+		return "", fmt.Errorf("%w: object %s matches %d objects", ErrAmbiguous, prefix, len(matches))
+	}
+	for hash := range matches {
+		return hash, nil
+	}
+	return "", fmt.Errorf("%w: object %s", ErrNotFound, prefix)
+}
+
+func (r *Repo) readLoose(hash string) (string, []byte, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "objects", hash[:2], hash[2:]))
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return "", nil, err
+	}
+	defer zr.Close()
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	header, data, ok := bytes.Cut(content, []byte{0})
+	if !ok {
+		return "", nil, fmt.Errorf("malformed object %s", hash)
+	}
+	kind, size, ok := strings.Cut(string(header), " ")
+	if !ok {
+		return "", nil, fmt.Errorf("malformed object %s", hash)
+	}
+	if n, err := strconv.Atoi(size); err != nil || n != len(data) {
+		return "", nil, fmt.Errorf("malformed object %s", hash)
+	}
+
+	return kind, data, nil
+}
+
+func (r *Repo) loadPacks() error {
+	if r.packs != nil {
+		return nil
+	}
+	idxs, err := filepath.Glob(filepath.Join(r.commonDir, "objects", "pack", "*.idx"))
+	if err != nil {
+		return err
+	}
+	r.packs = []*pack{}
+	for _, idx := range idxs {
+		p, err := openPack(idx)
+		if err != nil {
+			return err
+		}
+		r.packs = append(r.packs, p)
+	}
+	return nil
+}
+
+// pack is a packfile with its version 2 index.
+type pack struct {
+	path    string
+	fanout  [256]uint32
+	hashes  []byte
+	offsets []byte
+	large   []byte
+}
+
+func openPack(idxPath string) (*pack, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(idx) < 8+256*4 || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) || binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("unsupported pack index %s", idxPath)
+	}
+
+	p := &pack{path: strings.TrimSuffix(idxPath, ".idx") + ".pack"}
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(idx[8+i*4:])
+	}
+	n := int(p.fanout[255])
+	pos := 8 + 256*4
+	if len(idx) < pos+n*(20+4+4) {
+		return nil, fmt.Errorf("truncated pack index %s", idxPath)
+	}
+	p.hashes = idx[pos : pos+n*20]
+	pos += n * 20
+	// skip crc32 checksums
+	pos += n * 4
+	p.offsets = idx[pos : pos+n*4]
+	pos += n * 4
+	p.large = idx[pos:]
+
+	return p, nil
+}
+
+// find returns the packfile offset of an object.
+func (p *pack) find(hash []byte) (int64, bool) {
+	lo := 0
+	if hash[0] > 0 {
+		lo = int(p.fanout[hash[0]-1])
+	}
+	hi := int(p.fanout[hash[0]])
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch bytes.Compare(p.hashes[mid*20:mid*20+20], hash) {
+		case 0:
+			offset := binary.BigEndian.Uint32(p.offsets[mid*4:])
+			if offset&0x80000000 == 0 {
+				return int64(offset), true
+			}
+			i := int(offset & 0x7fffffff)
+			if len(p.large) < i*8+8 {
+				return 0, false
+			}
+			return int64(binary.BigEndian.Uint64(p.large[i*8:])), true
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+// findPrefix returns the hashes of the objects starting with a hex prefix.
+func (p *pack) findPrefix(prefix string) []string {
+	first, err := hex.DecodeString(prefix[:2])
+	if err != nil {
+		return nil
+	}
+	lo := 0
+	if first[0] > 0 {
+		lo = int(p.fanout[first[0]-1])
+	}
+	hi := int(p.fanout[first[0]])
+	var hashes []string
+	for i := lo; i < hi; i++ {
+		if hash := hex.EncodeToString(p.hashes[i*20 : i*20+20]); strings.HasPrefix(hash, prefix) {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// read returns type and content of the object at offset, resolving deltas.
+func (p *pack) read(r *Repo, offset int64) (int, []byte, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+	return p.readAt(r, f, offset, 0)
+}
+
+func (p *pack) readAt(r *Repo, f *os.File, offset int64, depth int) (int, []byte, error) {
+	if depth > 64 {
+		return 0, nil, fmt.Errorf("delta chain too deep in %s", p.path)
+	}
+
+	br := bufio.NewReader(io.NewSectionReader(f, offset, 1<<62))
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	typ := int(c>>4) & 7
+	size := uint64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		c, err = br.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		size |= uint64(c&0x7f) << shift
+	}
+
+	var baseType int
+	var base []byte
+	switch typ {
+	case objCommit, objTree, objBlob, objTag:
+	case objOfsDelta:
+		c, err = br.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		rel := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			c, err = br.ReadByte()
+			if err != nil {
+				return 0, nil, err
+			}
+			rel = ((rel + 1) << 7) | int64(c&0x7f)
+		}
+		baseType, base, err = p.readAt(r, f, offset-rel, depth+1)
+		if err != nil {
+			return 0, nil, err
+		}
+	case objRefDelta:
+		raw := make([]byte, 20)
+		if _, err := io.ReadFull(br, raw); err != nil {
+			return 0, nil, err
+		}
+		kind, data, err := r.readObject(hex.EncodeToString(raw))
+		if err != nil {
+			return 0, nil, err
+		}
+		for t, name := range objNames {
+			if name == kind {
+				baseType = t
+			}
+		}
+		base = data
+	default:
+		return 0, nil, fmt.Errorf("unknown object type %d in %s", typ, p.path)
+	}
+
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer zr.Close()
+	data := make([]byte, size)
+	if _, err := io.ReadFull(zr, data); err != nil {
+		return 0, nil, err
+	}
+
+	if base == nil {
+		return typ, data, nil
+	}
+	data, err = applyDelta(base, data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w in %s", err, p.path)
+	}
+	return baseType, data, nil
+}
+
+func applyDelta(base []byte, delta []byte) ([]byte, error) {
+	errDelta := errors.New("malformed delta")
+
+	varint := func() (int, bool) {
+		n, shift := 0, 0
+		for len(delta) > 0 {
+			c := delta[0]
+			delta = delta[1:]
+			n |= int(c&0x7f) << shift
+			shift += 7
+			if c&0x80 == 0 {
+				return n, true
+			}
+		}
+		return 0, false
+	}
+
+	srcSize, ok := varint()
+	if !ok || srcSize != len(base) {
+		return nil, errDelta
+	}
+	dstSize, ok := varint()
+	if !ok {
+		return nil, errDelta
+	}
+
+	out := make([]byte, 0, dstSize)
+	for len(delta) > 0 {
+		cmd := delta[0]
+		delta = delta[1:]
+		switch {
+		case cmd&0x80 != 0:
+			// copy from base
+			var offset, size int
+			for i := 0; i < 4; i++ {
+				if cmd&(1<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errDelta
+					}
+					offset |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			for i := 0; i < 3; i++ {
+				if cmd&(1<<(4+i)) != 0 {
+					if len(delta) == 0 {
+						return nil, errDelta
+					}
+					size |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if offset+size > len(base) {
+				return nil, errDelta
+			}
+			out = append(out, base[offset:offset+size]...)
+		case cmd != 0:
+			// insert literal data
+			if int(cmd) > len(delta) {
+				return nil, errDelta
+			}
+			out = append(out, delta[:cmd]...)
+			delta = delta[cmd:]
+		default:
+			return nil, errDelta
+		}
+	}
+	if len(out) != dstSize {
+		return nil, errDelta
+	}
+
+	return out, nil
+}
@@ -0,0 +1,342 @@
+// Package git reads refs, tags and commits of a local git repository
+// directly from its .git directory, without invoking git or touching the network.
+package git
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrNotRepo = errors.New("not a git repository")
+var ErrNotFound = errors.New("not found")
+var ErrAmbiguous = errors.New("ambiguous")
+
+// Repo is a local git repository.
+type Repo struct {
+	// directory holding HEAD, e.g. path/.git
+	gitDir string
+	// directory holding objects and refs, differs from gitDir for worktrees
+	commonDir string
+
+	packs []*pack
+}
+
+// Ref is a named pointer to an object.
+type Ref struct {
+	// short name, e.g. "v1.2.3" for refs/tags/v1.2.3
+	Name string
+	// object hash, might point to an annotated tag
+	Hash string
+}
+
+// Open will search path and its parent directories for a git repository.
+//
+// Open might return git.ErrNotRepo.
+func Open(path string) (*Repo, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if gitDir, ok := findGitDir(dir); ok {
+			return openGitDir(gitDir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("%w: %s", ErrNotRepo, path)
+		}
+		dir = parent
+	}
+}
+
+func findGitDir(dir string) (string, bool) {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err == nil && info.IsDir() {
+		return dotGit, true
+	}
+	if err == nil {
+		// worktrees and submodules link to their git directory
+		data, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", false
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !ok {
+			return "", false
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		return target, true
+	}
+	// bare repository
+	if isFile(filepath.Join(dir, "HEAD")) && isDir(filepath.Join(dir, "objects")) {
+		return dir, true
+	}
+	return "", false
+}
+
+func openGitDir(gitDir string) (*Repo, error) {
+	r := &Repo{gitDir: gitDir, commonDir: gitDir}
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+		r.commonDir = common
+	}
+	if !isDir(filepath.Join(r.commonDir, "objects")) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRepo, gitDir)
+	}
+	return r, nil
+}
+
+// Tags returns all tags of the repository, sorted by name.
+func (r *Repo) Tags() ([]Ref, error) {
+	refs, err := r.refs("refs/tags/")
+	if err != nil {
+		return nil, err
+	}
+	var tags []Ref
+	for name, hash := range refs {
+		tags = append(tags, Ref{Name: strings.TrimPrefix(name, "refs/tags/"), Hash: hash})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+// Resolve returns the object hash a revision points to.
+// A revision might be HEAD, a full ref name, the short name of a tag,
+// branch or remote branch, or a full or unique abbreviated object hash
+// of at least 4 digits, optionally followed by ancestry suffixes like
+// HEAD~2 or v1.2.3^2. Ref names take precedence over abbreviated hashes.
+//
+// Resolve might return git.ErrNotFound or git.ErrAmbiguous.
+func (r *Repo) Resolve(rev string) (string, error) {
+	i := strings.IndexAny(rev, "~^")
+	if i == -1 {
+		return r.resolveName(rev)
+	}
+	hash, err := r.resolveName(rev[:i])
+	if err != nil {
+		return "", err
+	}
+	for ops := rev[i:]; len(ops) > 0; {
+		op := ops[0]
+		ops = ops[1:]
+		n := 1
+		digits := len(ops) - len(strings.TrimLeft(ops, "0123456789"))
+		if digits > 0 {
+			n, err = strconv.Atoi(ops[:digits])
+			if err != nil {
+				return "", err
+			}
+			ops = ops[digits:]
+		}
+		if op == '^' {
+			hash, err = r.parent(hash, n)
+			if err != nil {
+				return "", fmt.Errorf("%w: revision %q", err, rev)
+			}
+			continue
+		}
+		for ; n > 0; n-- {
+			hash, err = r.parent(hash, 1)
+			if err != nil {
+				return "", fmt.Errorf("%w: revision %q", err, rev)
+			}
+		}
+	}
+	return hash, nil
+}
+
+// parent returns the n-th parent of a commit, the 0th parent is the commit itself.
+func (r *Repo) parent(hash string, n int) (string, error) {
+	hash, err := r.Peel(hash)
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return hash, nil
+	}
+	c, err := r.readCommit(hash)
+	if err != nil {
+		return "", err
+	}
+	if n > len(c.Parents) {
+		return "", ErrNotFound
+	}
+	return c.Parents[n-1], nil
+}
+
+func (r *Repo) resolveName(rev string) (string, error) {
+	if isHash(rev) {
+		return rev, nil
+	}
+	candidates := []string{rev}
+	if rev != "HEAD" && !strings.HasPrefix(rev, "refs/") {
+		candidates = append(candidates, "refs/tags/"+rev, "refs/heads/"+rev, "refs/remotes/"+rev)
+	}
+	for _, name := range candidates {
+		hash, err := r.resolveRef(name, 0)
+		if err == nil {
+			return hash, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+	}
+	if isAbbrevHash(rev) {
+		hash, err := r.expandHash(rev)
+		if !errors.Is(err, ErrNotFound) {
+			return hash, err
+		}
+	}
+	return "", fmt.Errorf("%w: revision %q", ErrNotFound, rev)
+}
+
+func (r *Repo) resolveRef(name string, depth int) (string, error) {
+	if depth > 10 {
+		return "", fmt.Errorf("symbolic ref loop at %q", name)
+	}
+
+	dir := r.commonDir
+	if name == "HEAD" {
+		dir = r.gitDir
+	}
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err == nil {
+		content := strings.TrimSpace(string(data))
+		if target, ok := strings.CutPrefix(content, "ref: "); ok {
+			return r.resolveRef(target, depth+1)
+		}
+		if !isHash(content) {
+			return "", fmt.Errorf("malformed ref %q", name)
+		}
+		return content, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	packed, err := r.packedRefs()
+	if err != nil {
+		return "", err
+	}
+	if hash, ok := packed[name]; ok {
+		return hash, nil
+	}
+	return "", fmt.Errorf("%w: ref %q", ErrNotFound, name)
+}
+
+// refs collects all refs below a namespace, loose refs shadow packed refs.
+func (r *Repo) refs(namespace string) (map[string]string, error) {
+	packed, err := r.packedRefs()
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string)
+	for name, hash := range packed {
+		if strings.HasPrefix(name, namespace) {
+			refs[name] = hash
+		}
+	}
+
+	root := filepath.Join(r.commonDir, filepath.FromSlash(namespace))
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(r.commonDir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		hash, err := r.resolveRef(name, 0)
+		if err != nil {
+			return err
+		}
+		refs[name] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func (r *Repo) packedRefs() (map[string]string, error) {
+	refs := make(map[string]string)
+
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return refs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// comments and peeled values of the preceding annotated tag
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		hash, name, ok := strings.Cut(line, " ")
+		if !ok || !isHash(hash) {
+			continue
+		}
+		refs[name] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func isHash(s string) bool {
+	return len(s) == 40 && isHex(s)
+}
+
+func isAbbrevHash(s string) bool {
+	return len(s) >= 4 && len(s) < 40 && isHex(s)
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,235 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testRepo creates a repository with the following history:
+//
+//	v0.1.0 - v1.0.0-rc.1 - v1.0.0 (annotated) - api/v1.4.0 - HEAD (main)
+//	                           \
+//	                            v1.1.0 (branch "side")
+func testRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_CONFIG_GLOBAL=/dev/null",
+			"GIT_CONFIG_NOSYSTEM=1",
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@example.org",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@example.org",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %s\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(msg string) {
+		t.Helper()
+		f, err := os.OpenFile(filepath.Join(dir, "file.txt"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.WriteString(strings.Repeat(msg+"\n", 50)); err != nil {
+			t.Fatal(err)
+		}
+		_ = f.Close()
+		run("add", "file.txt")
+		run("commit", "-q", "-m", msg)
+	}
+
+	run("init", "-q", "-b", "main")
+	day := 1
+	next := func(msg string) {
+		t.Helper()
+		t.Setenv("GIT_COMMITTER_DATE", "2024-01-"+strconv.Itoa(day)+"T12:00:00Z")
+		day++
+		commit(msg)
+	}
+	next("feat: initial")
+	run("tag", "v0.1.0")
+	next("feat: second")
+	run("tag", "v1.0.0-rc.1")
+	next("fix: third")
+	run("tag", "-a", "-m", "release", "v1.0.0")
+	run("checkout", "-q", "-b", "side")
+	next("feat: side")
+	run("tag", "v1.1.0")
+	run("checkout", "-q", "main")
+	next("feat(api): fourth")
+	run("tag", "api/v1.4.0")
+	run("tag", "not-a-version")
+
+	return dir
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %s\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := testRepo(t)
+	sub := filepath.Join(dir, "sub", "dir")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(sub); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotRepo) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
+
+func TestRepo_Tags(t *testing.T) {
+	dir := testRepo(t)
+	for _, packed := range []bool{false, true} {
+		if packed {
+			gitRun(t, dir, "pack-refs", "--all")
+			gitRun(t, dir, "repack", "-q", "-a", "-d", "-f")
+			gitRun(t, dir, "prune-packed")
+		}
+		t.Run("packed="+strconv.FormatBool(packed), func(t *testing.T) {
+			r, err := Open(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tags, err := r.Tags()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var names []string
+			for _, tag := range tags {
+				names = append(names, tag.Name)
+			}
+			expected := "api/v1.4.0 not-a-version v0.1.0 v1.0.0 v1.0.0-rc.1 v1.1.0"
+			if strings.Join(names, " ") != expected {
+				t.Errorf("unexpected tags:\nexpected = %s\nactual   = %s", expected, strings.Join(names, " "))
+			}
+
+			c, err := r.Commit("v1.0.0")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if strings.TrimSpace(c.Message) != "fix: third" {
+				t.Errorf("unexpected message: %q", c.Message)
+			}
+
+			log, err := r.Log("v1.0.0", "HEAD")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(log) != 1 || strings.TrimSpace(log[0].Message) != "feat(api): fourth" {
+				t.Errorf("unexpected log: %+v", log)
+			}
+
+			log, err = r.Log("", "side")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(log) != 4 || strings.TrimSpace(log[0].Message) != "feat: side" {
+				t.Errorf("unexpected log: %+v", log)
+			}
+		})
+	}
+}
+
+func TestRepo_ResolveNotFound(t *testing.T) {
+	r, err := Open(testRepo(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := r.Resolve("v9.9.9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error = %v", err)
+	}
+	if _, err := r.Resolve("v0.1.0~1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
+
+func TestRepo_ResolveAncestry(t *testing.T) {
+	r, err := Open(testRepo(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tests := []struct {
+		rev      string
+		expected string
+	}{
+		{rev: "HEAD~1", expected: "v1.0.0"},
+		{rev: "HEAD^", expected: "v1.0.0"},
+		{rev: "main~3", expected: "v0.1.0"},
+		{rev: "side^1~1", expected: "v1.0.0-rc.1"},
+		{rev: "v1.0.0^0", expected: "v1.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rev, func(t *testing.T) {
+			got, err := r.Commit(tt.rev)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want, err := r.Commit(tt.expected)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.Hash != want.Hash {
+				t.Errorf("unexpected commit:\nexpected = %s\nactual   = %s", want.Hash, got.Hash)
+			}
+		})
+	}
+}
+
+func TestRepo_ResolveAbbrev(t *testing.T) {
+	dir := testRepo(t)
+	for _, packed := range []bool{false, true} {
+		if packed {
+			gitRun(t, dir, "repack", "-q", "-a", "-d", "-f")
+			gitRun(t, dir, "prune-packed")
+		}
+		t.Run("packed="+strconv.FormatBool(packed), func(t *testing.T) {
+			r, err := Open(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			c, err := r.Commit("v1.0.0")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, rev := range []string{c.Hash[:7], c.Hash[:12], c.Hash[:7] + "~0"} {
+				hash, err := r.Resolve(rev)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if hash != c.Hash {
+					t.Errorf("unexpected hash:\nexpected = %s\nactual   = %s", c.Hash, hash)
+				}
+			}
+			for _, rev := range []string{c.Hash[:3], "0000000", "fffffff"} {
+				if _, err := r.Resolve(rev); !errors.Is(err, ErrNotFound) {
+					t.Errorf("unexpected error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+// Versions returns the versions of all tags starting with prefix, sorted in
+// descending natural order. Tags that are no valid semver after removing the
+// prefix are skipped. If reachable is set, only tags reachable from HEAD are considered.
+func (r *Repo) Versions(prefix string, reachable bool) ([]semver.Version, error) {
+	tags, err := r.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var ancestors map[string]bool
+	if reachable {
+		ancestors, err = r.Ancestors("HEAD")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var vers []semver.Version
+	for _, tag := range tags {
+		str, ok := strings.CutPrefix(tag.Name, prefix)
+		if !ok {
+			continue
+		}
+		ver, err := semver.Parse(str)
+		if err != nil {
+			continue
+		}
+		if reachable {
+			hash, err := r.Peel(tag.Hash)
+			if err != nil {
+				return nil, err
+			}
+			if !ancestors[hash] {
+				continue
+			}
+		}
+		vers = append(vers, ver)
+	}
+
+	return semver.SortDesc(vers), nil
+}
+
+// Latest returns the newest release of all tags starting with prefix.
+//
+// Latest might return git.ErrNotFound.
+func (r *Repo) Latest(prefix string, reachable bool) (semver.Version, error) {
+	vers, err := r.Versions(prefix, reachable)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	ver, ok := semver.Latest(vers)
+	if !ok {
+		return semver.Version{}, ErrNotFound
+	}
+	return ver, nil
+}
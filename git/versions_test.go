@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepo_Versions(t *testing.T) {
+	r, err := Open(testRepo(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		name      string
+		prefix    string
+		reachable bool
+		expected  string
+		latest    string
+	}{
+		{
+			name:     "all",
+			prefix:   "v",
+			expected: "1.1.0 1.0.0 1.0.0-rc.1 0.1.0",
+			latest:   "1.1.0",
+		},
+		{
+			name:      "reachable from HEAD",
+			prefix:    "v",
+			reachable: true,
+			expected:  "1.0.0 1.0.0-rc.1 0.1.0",
+			latest:    "1.0.0",
+		},
+		{
+			name:     "component prefix",
+			prefix:   "api/v",
+			expected: "1.4.0",
+			latest:   "1.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vers, err := r.Versions(tt.prefix, tt.reachable)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var strs string
+			for i, ver := range vers {
+				if i > 0 {
+					strs += " "
+				}
+				strs += ver.String()
+			}
+			if strs != tt.expected {
+				t.Errorf("unexpected versions:\nexpected = %s\nactual   = %s", tt.expected, strs)
+			}
+
+			latest, err := r.Latest(tt.prefix, tt.reachable)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if latest.String() != tt.latest {
+				t.Errorf("unexpected latest:\nexpected = %s\nactual   = %s", tt.latest, latest.String())
+			}
+		})
+	}
+
+	if _, err := r.Latest("web/v", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
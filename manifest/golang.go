@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+
+	"github.com/nothub/semver"
+)
+
+// Go handles Go sources declaring the version as package level string constant or variable.
+type Go struct{}
+
+var goKeys = []string{"Version", "version"}
+
+func (Go) Name() string {
+	return "go"
+}
+
+func (Go) Match(path string) bool {
+	return filepath.Ext(path) == ".go"
+}
+
+// Find returns the string literal assigned to the identifier key,
+// defaulting to Version and version.
+func (Go) Find(data []byte, key string) (int, int, error) {
+	keys := goKeys
+	if key != "" {
+		keys = []string{key}
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", data, parser.SkipObjectResolution)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, k := range keys {
+		lit := findGoLiteral(file, k)
+		if lit == nil {
+			continue
+		}
+		start := fset.Position(lit.Pos()).Offset + 1
+		end := fset.Position(lit.End()).Offset - 1
+		// the literal is the raw string if it contains no escape sequences
+		if str, err := strconv.Unquote(lit.Value); err != nil || str != string(data[start:end]) {
+			return 0, 0, ErrNotFound
+		}
+		return start, end, nil
+	}
+	return 0, 0, ErrNotFound
+}
+
+// Format formats the result with gofmt if the original data is formatted,
+// so aligned comments follow a version of different length.
+func (Go) Format(orig []byte, data []byte) []byte {
+	if formatted, err := format.Source(orig); err != nil || !bytes.Equal(formatted, orig) {
+		return data
+	}
+	formatted, err := format.Source(data)
+	if err != nil {
+		return data
+	}
+	return formatted
+}
+
+// findGoLiteral returns the string literal assigned to a package level constant or variable.
+func findGoLiteral(file *ast.File, name string) *ast.BasicLit {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, ident := range vs.Names {
+				if ident.Name != name || i >= len(vs.Values) {
+					continue
+				}
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					return lit
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RewriteGo replaces the version assigned to the package level string constant
+// or variable name in Go source, keeping gofmt formatting.
+//
+// RewriteGo might return manifest.ErrNotFound or semver.ErrInvalid.
+func RewriteGo(src []byte, name string, ver semver.Version) ([]byte, error) {
+	start, end, err := Go{}.Find(src, name)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := semver.Parse(string(src[start:end]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, src[start:end])
+	}
+	f := File{Handler: Go{}, Data: src, Start: start, End: end, Version: cur}
+	return f.Replace(ver), nil
+}
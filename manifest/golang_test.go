@@ -0,0 +1,100 @@
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func TestRewriteGo(t *testing.T) {
+	tests := []struct {
+		name  string
+		ident string
+		src   string
+		want  string
+	}{
+		{
+			name:  "const",
+			ident: "Version",
+			src:   "package main\n\nconst Version = \"1.2.3\"\n",
+			want:  "package main\n\nconst Version = \"1.2.10\"\n",
+		},
+		{
+			name:  "aligned block",
+			ident: "version",
+			src:   "package main\n\nconst (\n\tname    = \"app\"   // name\n\tversion = \"1.2.3\" // version\n)\n",
+			want:  "package main\n\nconst (\n\tname    = \"app\"    // name\n\tversion = \"1.2.10\" // version\n)\n",
+		},
+		{
+			name:  "multiple names",
+			ident: "Version",
+			src:   "package main\n\nvar Name, Version = \"app\", `1.2.3`\n",
+			want:  "package main\n\nvar Name, Version = \"app\", `1.2.10`\n",
+		},
+		{
+			name:  "typed var",
+			ident: "buildVersion",
+			src:   "package main\n\nvar buildVersion string = \"1.2.3\"\n",
+			want:  "package main\n\nvar buildVersion string = \"1.2.10\"\n",
+		},
+		{
+			name:  "unformatted source is not formatted",
+			ident: "Version",
+			src:   "package main\nconst   Version=\"1.2.3\" // v\nconst x  = 1\n",
+			want:  "package main\nconst   Version=\"1.2.10\" // v\nconst x  = 1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RewriteGo([]byte(tt.src), tt.ident, semver.MustParse("1.2.10"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("unexpected result:\nexpected = %q\nactual   = %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRewriteGoErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  error
+	}{
+		{
+			name: "local constant",
+			src:  "package main\n\nfunc main() {\n\tconst Version = \"1.2.3\"\n}\n",
+			err:  ErrNotFound,
+		},
+		{
+			name: "no string",
+			src:  "package main\n\nconst Version = 3\n",
+			err:  ErrNotFound,
+		},
+		{
+			name: "escape sequence",
+			src:  "package main\n\nconst Version = \"1.2.\\x33\"\n",
+			err:  ErrNotFound,
+		},
+		{
+			name: "invalid version",
+			src:  "package main\n\nconst Version = \"v1.2.3\"\n",
+			err:  semver.ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RewriteGo([]byte(tt.src), "Version", semver.MustParse("2.0.0"))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+
+	if _, err := RewriteGo([]byte("package"), "Version", semver.MustParse("2.0.0")); err == nil {
+		t.Error("syntax error should fail")
+	}
+}
@@ -0,0 +1,195 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Text handles files holding nothing but the version, like VERSION.
+type Text struct{}
+
+func (Text) Name() string {
+	return "text"
+}
+
+func (Text) Match(path string) bool {
+	base := filepath.Base(path)
+	return base == "VERSION" || base == "VERSION.txt"
+}
+
+// Find returns the first non-empty line, the key is ignored.
+func (Text) Find(data []byte, key string) (int, int, error) {
+	offset := 0
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" {
+			start := offset + strings.Index(line, trimmed)
+			return start, start + len(trimmed), nil
+		}
+		offset += len(line)
+	}
+	return 0, 0, ErrNotFound
+}
+
+// JSON handles json files like package.json.
+type JSON struct{}
+
+func (JSON) Name() string {
+	return "json"
+}
+
+func (JSON) Match(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// Find returns the string value at key, a dot separated path of object members,
+// defaulting to the top level "version" member.
+func (JSON) Find(data []byte, key string) (int, int, error) {
+	if key == "" {
+		key = "version"
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	end, ok, err := findJSON(dec, nil, strings.Split(key, "."))
+	if err != nil {
+		return 0, 0, err
+	}
+	if !ok {
+		return 0, 0, ErrNotFound
+	}
+	// the literal is the raw string if it contains no escape sequences
+	start := bytes.LastIndexByte(data[:end], '"') + 1
+	if start < 1 || bytes.IndexByte(data[start:end], '\\') >= 0 {
+		return 0, 0, ErrNotFound
+	}
+	return start, end, nil
+}
+
+// findJSON walks the next value of dec and returns the offset of the closing
+// quote of the string found at path want.
+func findJSON(dec *json.Decoder, path []string, want []string) (int, bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, false, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return 0, false, err
+			}
+			name, _ := tok.(string)
+			end, ok, err := findJSON(dec, append(path, name), want)
+			if ok || err != nil {
+				return end, ok, err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for dec.More() {
+			end, ok, err := findJSON(dec, append(path, "[]"), want)
+			if ok || err != nil {
+				return end, ok, err
+			}
+		}
+		_, err = dec.Token()
+	default:
+		if _, ok := tok.(string); ok && slices.Equal(path, want) {
+			return int(dec.InputOffset()) - 1, true, nil
+		}
+	}
+	return 0, false, err
+}
+
+// TOML handles toml files like Cargo.toml and pyproject.toml.
+type TOML struct{}
+
+var tomlKeys = []string{"package.version", "workspace.package.version", "project.version", "tool.poetry.version"}
+
+var tomlTable = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.-]+)\s*]\s*(#.*)?$`)
+var tomlValue = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+)\s*=\s*(?:"([^"\\]*)"|'([^']*)')\s*(#.*)?$`)
+
+func (TOML) Name() string {
+	return "toml"
+}
+
+func (TOML) Match(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// Find returns the string value at key, the table and name of the value
+// separated by a dot. Defaults are package.version and workspace.package.version
+// of Cargo.toml and project.version and tool.poetry.version of pyproject.toml.
+func (TOML) Find(data []byte, key string) (int, int, error) {
+	keys := tomlKeys
+	if key != "" {
+		keys = []string{key}
+	}
+
+	found := make(map[string][2]int)
+	table := ""
+	offset := 0
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if m := tomlTable.FindStringSubmatch(trimmed); m != nil {
+			table = m[1]
+		} else if m := tomlValue.FindStringSubmatchIndex(trimmed); m != nil {
+			name := line[m[2]:m[3]]
+			if table != "" {
+				name = table + "." + name
+			}
+			if _, ok := found[name]; !ok {
+				if m[4] >= 0 {
+					found[name] = [2]int{offset + m[4], offset + m[5]}
+				} else {
+					found[name] = [2]int{offset + m[6], offset + m[7]}
+				}
+			}
+		}
+		offset += len(line)
+	}
+
+	for _, k := range keys {
+		if pos, ok := found[k]; ok {
+			return pos[0], pos[1], nil
+		}
+	}
+	return 0, 0, ErrNotFound
+}
+
+// YAML handles yaml files like Chart.yaml.
+type YAML struct{}
+
+var yamlValue = regexp.MustCompile(`^([A-Za-z0-9_-]+):[ \t]*(?:"([^"\\]*)"|'([^']*)'|([^\s"'#][^\s#]*))[ \t]*(#.*)?$`)
+
+func (YAML) Name() string {
+	return "yaml"
+}
+
+func (YAML) Match(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Find returns the scalar value of the top level mapping key, defaulting to version.
+func (YAML) Find(data []byte, key string) (int, int, error) {
+	if key == "" {
+		key = "version"
+	}
+	offset := 0
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if m := yamlValue.FindStringSubmatchIndex(strings.TrimRight(line, "\r\n")); m != nil && line[m[2]:m[3]] == key {
+			for i := 4; i < 10; i += 2 {
+				if m[i] >= 0 {
+					return offset + m[i], offset + m[i+1], nil
+				}
+			}
+		}
+		offset += len(line)
+	}
+	return 0, 0, ErrNotFound
+}
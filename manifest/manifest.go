@@ -0,0 +1,149 @@
+// Package manifest locates and replaces the version declared in project files
+// like package.json, Cargo.toml, pyproject.toml, Chart.yaml, VERSION or Go sources.
+//
+// Only the version string itself is replaced, formatting and comments of the
+// surrounding file are preserved.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nothub/semver"
+)
+
+var ErrUnsupported = errors.New("unsupported file")
+var ErrNotFound = errors.New("version not found")
+
+// Handler locates the version in the content of a file format.
+type Handler interface {
+	// Name of the format, e.g. json.
+	Name() string
+	// Match reports if the handler supports the file at path.
+	Match(path string) bool
+	// Find returns the offsets of the version string in data.
+	// The key selects the field or identifier holding the version,
+	// the handler falls back to its defaults if key is empty.
+	//
+	// Find might return manifest.ErrNotFound.
+	Find(data []byte, key string) (start int, end int, err error)
+}
+
+// Formatter is implemented by handlers reformatting files after the version was replaced.
+type Formatter interface {
+	// Format returns data, the original content orig with the version replaced, formatted.
+	Format(orig []byte, data []byte) []byte
+}
+
+// Handlers are consulted in order to find the Handler of a file.
+var Handlers = []Handler{
+	Text{},
+	JSON{},
+	TOML{},
+	YAML{},
+	Go{},
+}
+
+// Register adds a Handler, taking precedence over all previously registered handlers.
+func Register(h Handler) {
+	Handlers = append([]Handler{h}, Handlers...)
+}
+
+// Lookup returns the Handler of the file at path.
+//
+// Lookup might return manifest.ErrUnsupported.
+func Lookup(path string) (Handler, error) {
+	for _, h := range Handlers {
+		if h.Match(path) {
+			return h, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupported, path)
+}
+
+// File is a version located in the content of a file.
+type File struct {
+	Path    string
+	Handler Handler
+	Data    []byte
+	// offsets of the version string in Data
+	Start   int
+	End     int
+	Version semver.Version
+}
+
+// Read will attempt to locate the version in the file at path.
+// The key selects the field or identifier holding the version, see Handler.
+//
+// Read might return manifest.ErrUnsupported, manifest.ErrNotFound or semver.ErrInvalid.
+func Read(path string, key string) (*File, error) {
+	h, err := Lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(path, data, h, key)
+}
+
+// Load will attempt to locate the version in data with a Handler.
+//
+// Load might return manifest.ErrNotFound or semver.ErrInvalid.
+func Load(path string, data []byte, h Handler, key string) (*File, error) {
+	start, end, err := h.Find(data, key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	ver, err := semver.Parse(string(data[start:end]))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %q", path, err, data[start:end])
+	}
+	return &File{Path: path, Handler: h, Data: data, Start: start, End: end, Version: ver}, nil
+}
+
+// Replace returns the content of the File with the version replaced.
+func (f *File) Replace(ver semver.Version) []byte {
+	var out []byte
+	out = append(out, f.Data[:f.Start]...)
+	out = append(out, ver.String()...)
+	out = append(out, f.Data[f.End:]...)
+	if fm, ok := f.Handler.(Formatter); ok {
+		return fm.Format(f.Data, out)
+	}
+	return out
+}
+
+// Write replaces the version of the File and writes it back to its path.
+func (f *File) Write(ver semver.Version) error {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, f.Replace(ver), info.Mode().Perm())
+}
+
+// Diff returns the change of replacing the version of the File as unified diff.
+func (f *File) Diff(ver semver.Version) string {
+	lineStart := strings.LastIndexByte(string(f.Data[:f.Start]), '\n') + 1
+	lineEnd := len(f.Data)
+	if i := strings.IndexByte(string(f.Data[f.End:]), '\n'); i >= 0 {
+		lineEnd = f.End + i
+	}
+	line := strings.Count(string(f.Data[:f.Start]), "\n") + 1
+
+	sb := strings.Builder{}
+	_, _ = fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", f.Path, f.Path)
+	_, _ = fmt.Fprintf(&sb, "@@ -%d +%d @@\n", line, line)
+	sb.WriteString("-")
+	sb.Write(f.Data[lineStart:lineEnd])
+	sb.WriteString("\n+")
+	sb.Write(f.Data[lineStart:f.Start])
+	sb.WriteString(ver.String())
+	sb.Write(f.Data[f.End:lineEnd])
+	sb.WriteString("\n")
+	return sb.String()
+}
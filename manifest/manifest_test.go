@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nothub/semver"
+)
+
+func TestHandlers(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		key  string
+		data string
+		want string
+	}{
+		{
+			name: "version file",
+			path: "VERSION",
+			data: "\n  1.2.3  \n",
+			want: "\n  1.3.0  \n",
+		},
+		{
+			name: "package.json",
+			path: "package.json",
+			data: "{\n  \"name\": \"x\",\n  \"dependencies\": {\"version\": \"0.1.0\"},\n  \"version\":  \"1.2.3\" ,\n  \"scripts\": {}\n}\n",
+			want: "{\n  \"name\": \"x\",\n  \"dependencies\": {\"version\": \"0.1.0\"},\n  \"version\":  \"1.3.0\" ,\n  \"scripts\": {}\n}\n",
+		},
+		{
+			name: "json nested key",
+			path: "app.json",
+			key:  "expo.version",
+			data: `{"list": [{"version": "0.0.1"}], "expo": {"name": "x", "version": "1.2.3"}}`,
+			want: `{"list": [{"version": "0.0.1"}], "expo": {"name": "x", "version": "1.3.0"}}`,
+		},
+		{
+			name: "Cargo.toml",
+			path: "Cargo.toml",
+			data: "[package]\nname = \"x\"\nversion = \"1.2.3\" # keep\n\n[dependencies]\nserde = { version = \"1.0.0\" }\n",
+			want: "[package]\nname = \"x\"\nversion = \"1.3.0\" # keep\n\n[dependencies]\nserde = { version = \"1.0.0\" }\n",
+		},
+		{
+			name: "Cargo.toml workspace",
+			path: "Cargo.toml",
+			data: "[workspace]\nmembers = []\n\n[workspace.package]\nversion = '1.2.3'\n",
+			want: "[workspace]\nmembers = []\n\n[workspace.package]\nversion = '1.3.0'\n",
+		},
+		{
+			name: "pyproject.toml",
+			path: "pyproject.toml",
+			data: "[build-system]\nrequires = []\n\n[project]\r\nname = \"x\"\r\nversion = \"1.2.3\"\r\n",
+			want: "[build-system]\nrequires = []\n\n[project]\r\nname = \"x\"\r\nversion = \"1.3.0\"\r\n",
+		},
+		{
+			name: "poetry",
+			path: "pyproject.toml",
+			data: "[tool.poetry]\nversion = \"1.2.3\"\n",
+			want: "[tool.poetry]\nversion = \"1.3.0\"\n",
+		},
+		{
+			name: "Chart.yaml",
+			path: "Chart.yaml",
+			data: "apiVersion: v2\nname: x\nversion: 1.2.3 # chart\nappVersion: \"2.0.0\"\ndependencies:\n  - version: 0.1.0\n",
+			want: "apiVersion: v2\nname: x\nversion: 1.3.0 # chart\nappVersion: \"2.0.0\"\ndependencies:\n  - version: 0.1.0\n",
+		},
+		{
+			name: "Chart.yaml appVersion",
+			path: "Chart.yaml",
+			key:  "appVersion",
+			data: "version: 0.1.0\nappVersion: \"1.2.3\"\n",
+			want: "version: 0.1.0\nappVersion: \"1.3.0\"\n",
+		},
+		{
+			name: "go const",
+			path: "version.go",
+			data: "package main\n\n// Version of the app.\nconst Version = \"1.2.3\"\n",
+			want: "package main\n\n// Version of the app.\nconst Version = \"1.3.0\"\n",
+		},
+		{
+			name: "go var with key",
+			path: "main.go",
+			key:  "appVersion",
+			data: "package main\n\nvar appVersion string = \"1.2.3\"\n",
+			want: "package main\n\nvar appVersion string = \"1.3.0\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := Lookup(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			f, err := Load(tt.path, []byte(tt.data), h, tt.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if f.Version.String() != "1.2.3" {
+				t.Errorf("unexpected version: %s", f.Version.String())
+			}
+			got := string(f.Replace(f.Version.NextMinor()))
+			if got != tt.want {
+				t.Errorf("unexpected result:\nexpected = %q\nactual   = %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		err  error
+	}{
+		{path: "VERSION", data: "\n\n", err: ErrNotFound},
+		{path: "VERSION", data: "1.2", err: semver.ErrInvalid},
+		{path: "package.json", data: `{"name": "x"}`, err: ErrNotFound},
+		{path: "Cargo.toml", data: "[dependencies]\nversion = \"1.0.0\"\n", err: ErrNotFound},
+		{path: "Chart.yaml", data: "dependencies:\n  version: 1.0.0\n", err: ErrNotFound},
+		{path: "main.go", data: "package main\n", err: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			h, err := Lookup(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_, err = Load(tt.path, []byte(tt.data), h, "")
+			if !errors.Is(err, tt.err) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+
+	if _, err := Lookup("Makefile"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}
+
+type fixed struct{}
+
+func (fixed) Name() string                                   { return "fixed" }
+func (fixed) Match(path string) bool                         { return filepath.Base(path) == "VERSION" }
+func (fixed) Find(data []byte, key string) (int, int, error) { return 2, 7, nil }
+
+func TestRegister(t *testing.T) {
+	defer func(hs []Handler) { Handlers = hs }(Handlers)
+	Register(fixed{})
+
+	h, err := Lookup("VERSION")
+	if err != nil || h.Name() != "fixed" {
+		t.Fatalf("unexpected handler = %v, error = %v", h, err)
+	}
+}
+
+func TestFile_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "VERSION")
+	if err := os.WriteFile(path, []byte("1.2.3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Read(path, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diff := f.Diff(semver.MustParse("2.0.0"))
+	want := "--- a/" + path + "\n+++ b/" + path + "\n@@ -1 +1 @@\n-1.2.3\n+2.0.0\n"
+	if diff != want {
+		t.Errorf("unexpected result:\nexpected = %q\nactual   = %q", want, diff)
+	}
+
+	if err := f.Write(semver.MustParse("2.0.0")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "2.0.0\n" {
+		t.Errorf("unexpected content: %q", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("unexpected mode: %v", info.Mode())
+	}
+}
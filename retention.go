@@ -0,0 +1,156 @@
+package semver
+
+import (
+	"cmp"
+	"slices"
+)
+
+// RetentionPolicy selects the versions kept in a registry, like the
+// images of a container repository. Of every minor only the latest patch
+// is kept, negative limits keep all majors, minors or pre-releases.
+type RetentionPolicy struct {
+	// releases of the newest majors are kept
+	Majors int
+	// newest minors of every kept major, of which the latest patch is kept
+	Minors int
+	// newest pre-releases of every channel, the first pre-release identifier
+	PreReleases int
+	// prefix of the version tags pruned, as in TagPlan
+	Prefix string
+	// variant of the tags pruned, as in TagPlan. Without a variant,
+	// variant tags like 1.2.0-alpine are pruned as pre-releases.
+	Variant string
+}
+
+// Retain splits versions into the ones to keep and the ones to drop,
+// both in the order of vers. Of the releases the latest patch of the newest
+// minors of the newest majors is kept, of the pre-releases the newest of
+// every channel. Versions only differing in build metadata are kept together.
+func (p *RetentionPolicy) Retain(vers []Version) (keep []Version, drop []Version) {
+	var releases, pres []Version
+	for _, ver := range vers {
+		if ver.IsRelease() {
+			releases = append(releases, ver)
+		} else {
+			pres = append(pres, ver)
+		}
+	}
+
+	kept := make(map[string]bool)
+	support := SupportPolicy{Majors: p.Majors, Minors: p.Minors}
+	for _, line := range support.Maintained(releases) {
+		kept[precedence(line.Latest)] = true
+	}
+	channels := GroupBy(pres, preReleaseChannel)
+	for _, channel := range newestGroups(channels, -1) {
+		for i, ver := range channel {
+			if p.PreReleases >= 0 && i >= p.PreReleases {
+				break
+			}
+			kept[precedence(ver)] = true
+		}
+	}
+
+	for _, ver := range vers {
+		if kept[precedence(ver)] {
+			keep = append(keep, ver)
+		} else {
+			drop = append(drop, ver)
+		}
+	}
+	return keep, drop
+}
+
+// Prune splits container tags into the ones to keep and the ones to delete,
+// both in the order of tags. Version tags carrying the prefix and variant of
+// the policy are decoded with ParseContainerTag and retained according to the
+// policy. Floating tags like 1.2, 1-rc or latest, as rendered by TagPlan, are
+// kept along with the newest version they refer to. Tags that are neither,
+// like the tags of other variants, are kept.
+func (p *RetentionPolicy) Prune(tags []string) (keep []string, drop []string) {
+	var vers []Version
+	var floating []string
+	for _, tag := range tags {
+		if ver, ok := p.decode(tag); ok {
+			vers = append(vers, ver)
+		} else {
+			floating = append(floating, tag)
+		}
+	}
+
+	kept := make(map[string]bool)
+	retained, _ := p.Retain(vers)
+	for _, ver := range retained {
+		kept[precedence(ver)] = true
+	}
+	for _, tag := range floating {
+		if ver, ok := p.floatingTarget(tag, vers); ok {
+			kept[precedence(ver)] = true
+		}
+	}
+
+	for _, tag := range tags {
+		ver, ok := p.decode(tag)
+		if !ok || kept[precedence(ver)] {
+			keep = append(keep, tag)
+		} else {
+			drop = append(drop, tag)
+		}
+	}
+	return keep, drop
+}
+
+// floatingPlans render every floating tag a version might be referenced by.
+var floatingPlans = []TagPlan{
+	{PreRelease: PreReleaseChannelFloating, Channel: "latest"},
+	{PreRelease: PreReleaseFloating},
+}
+
+// decode returns the version of a tag carrying the prefix and variant of the policy.
+func (p *RetentionPolicy) decode(tag string) (Version, bool) {
+	plan := TagPlan{Prefix: p.Prefix, Variant: p.Variant}
+	ver, err := plan.ParseTag(tag)
+	return ver, err == nil
+}
+
+// floatingTarget returns the newest version referenced by a floating tag.
+func (p *RetentionPolicy) floatingTarget(tag string, vers []Version) (target Version, ok bool) {
+	for _, ver := range vers {
+		if ok && Compare(ver, target) <= 0 {
+			continue
+		}
+		for _, plan := range floatingPlans {
+			plan.Prefix, plan.Variant = p.Prefix, p.Variant
+			tags, _ := plan.Tags(ver)
+			if slices.ContainsFunc(tags, func(t Tag) bool {
+				return t.Name == tag && t.Kind != TagExact && t.Kind != TagBuild
+			}) {
+				target, ok = ver, true
+				break
+			}
+		}
+	}
+	return target, ok
+}
+
+// newestGroups sorts every group in descending natural order and returns
+// the n groups with the newest versions, all if n is negative.
+func newestGroups(groups map[string][]Version, n int) [][]Version {
+	var sorted [][]Version
+	for _, group := range groups {
+		sorted = append(sorted, SortDesc(group))
+	}
+	slices.SortFunc(sorted, func(a, b []Version) int {
+		return cmp.Or(Compare(b[0], a[0]), cmp.Compare(b[0].String(), a[0].String()))
+	})
+	if n >= 0 && n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// precedence returns the string of a version without build metadata.
+func precedence(ver Version) string {
+	ver.Build = nil
+	return ver.String()
+}
@@ -0,0 +1,92 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetentionPolicy_Retain(t *testing.T) {
+	vers := MustParseAll([]string{
+		"3.0.0-beta.1", "3.0.0-rc.1", "3.0.0-rc.2",
+		"2.1.1", "2.1.0", "2.0.3", "2.0.3+build.2", "2.0.2",
+		"1.4.0", "1.3.9", "1.3.8",
+		"0.9.0",
+	})
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		keep   string
+	}{
+		{
+			name:   "keep all",
+			policy: RetentionPolicy{Majors: -1, Minors: -1, PreReleases: -1},
+			keep:   "3.0.0-beta.1 3.0.0-rc.1 3.0.0-rc.2 2.1.1 2.0.3 2.0.3+build.2 1.4.0 1.3.9 0.9.0",
+		},
+		{
+			name:   "newest majors and minors",
+			policy: RetentionPolicy{Majors: 2, Minors: 1, PreReleases: 1},
+			keep:   "3.0.0-beta.1 3.0.0-rc.2 2.1.1 1.4.0",
+		},
+		{
+			name:   "releases only",
+			policy: RetentionPolicy{Majors: 1, Minors: 2, PreReleases: 0},
+			keep:   "2.1.1 2.0.3 2.0.3+build.2",
+		},
+		{
+			name:   "nothing",
+			policy: RetentionPolicy{},
+			keep:   "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keep, drop := test.policy.Retain(vers)
+			if len(keep)+len(drop) != len(vers) {
+				t.Errorf("unexpected count: %d + %d", len(keep), len(drop))
+			}
+			var strs []string
+			for _, ver := range keep {
+				strs = append(strs, ver.String())
+			}
+			if actual := strings.Join(strs, " "); actual != test.keep {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.keep, actual)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicy_Prune(t *testing.T) {
+	tags := []string{
+		"latest", "2", "2.1", "1", "1.3", "0", "rc", "2-rc", "edge",
+		"2.1.0-rc.1", "2.1.0-rc.2", "2.1.1", "2.1.0", "2.0.0", "1.4.0-beta.1", "1.3.2", "1.3.1", "1.3.1_git.a1b2", "0.5.0", "0.4.0",
+	}
+	policy := RetentionPolicy{Majors: 1, Minors: 1, PreReleases: 0}
+	keep, drop := policy.Prune(tags)
+
+	expectedKeep := "latest 2 2.1 1 1.3 0 rc 2-rc edge 2.1.0-rc.2 2.1.1 1.3.2 0.5.0"
+	if actual := strings.Join(keep, " "); actual != expectedKeep {
+		t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", expectedKeep, actual)
+	}
+	expectedDrop := "2.1.0-rc.1 2.1.0 2.0.0 1.4.0-beta.1 1.3.1 1.3.1_git.a1b2 0.4.0"
+	if actual := strings.Join(drop, " "); actual != expectedDrop {
+		t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", expectedDrop, actual)
+	}
+}
+
+func TestRetentionPolicy_Prune_variant(t *testing.T) {
+	tags := []string{
+		"alpine", "v1-alpine", "latest", "1",
+		"v1.3.0-rc.1-alpine", "v1.3.0-alpine", "v1.2.1-alpine", "v1.2.0-alpine", "1.3.0", "1.2.0", "1.3.0-alpine",
+	}
+	policy := RetentionPolicy{Majors: -1, Minors: 1, PreReleases: 0, Prefix: "v", Variant: "alpine"}
+	keep, drop := policy.Prune(tags)
+
+	expectedKeep := "alpine v1-alpine latest 1 v1.3.0-alpine 1.3.0 1.2.0 1.3.0-alpine"
+	if actual := strings.Join(keep, " "); actual != expectedKeep {
+		t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", expectedKeep, actual)
+	}
+	expectedDrop := "v1.3.0-rc.1-alpine v1.2.1-alpine v1.2.0-alpine"
+	if actual := strings.Join(drop, " "); actual != expectedDrop {
+		t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", expectedDrop, actual)
+	}
+}
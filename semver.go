@@ -25,7 +25,9 @@ type Version struct {
 var ErrInvalid = errors.New("invalid semver string")
 
 // https://regex101.com/r/vkijKf/1/
-var regex = regexp.MustCompile("^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$")
+const pattern = "(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?"
+
+var regex = regexp.MustCompile("^" + pattern + "$")
 
 // Parse will attempt to convert a string to a semver.Version struct.
 //
@@ -102,6 +104,24 @@ func (v *Version) IsRelease() bool {
 	return len(v.PreRelease) == 0
 }
 
+// NextMajor returns the next major version.
+// Minor and patch are reset, pre-release and build metadata are dropped.
+func (v *Version) NextMajor() Version {
+	return Version{Major: v.Major + 1}
+}
+
+// NextMinor returns the next minor version.
+// Patch is reset, pre-release and build metadata are dropped.
+func (v *Version) NextMinor() Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// NextPatch returns the next patch version.
+// Pre-release and build metadata are dropped.
+func (v *Version) NextPatch() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
 // Newer returns true if a is newer than b.
 // Build metadata is ignored in this comparison.
 func (a *Version) Newer(b Version) bool {
@@ -141,6 +161,22 @@ func Compare(a Version, b Version) int {
 	return comparePreRelease(a, b)
 }
 
+// CompareBuild works like Compare, but versions of same precedence are
+// additionally ordered by build metadata, following the rules for pre-release
+// identifiers. A version without build metadata is older than one with.
+//
+//	CompareBuild(MustParse("1.0.0"), MustParse("1.0.0+1")) -> -1
+//	CompareBuild(MustParse("1.0.0+2"), MustParse("1.0.0+10")) -> -1
+//
+// Note that the semver specification ignores build metadata for precedence.
+func CompareBuild(a Version, b Version) int {
+	result := Compare(a, b)
+	if result != 0 {
+		return result
+	}
+	return compareIdentifiers(a.Build, b.Build)
+}
+
 func compareInt(a int, b int) int {
 	if a > b {
 		return +1
@@ -172,37 +208,41 @@ func comparePreRelease(a Version, b Version) int {
 		return -1
 	}
 
-	for i := 0; i < max(len(a.PreRelease), len(b.PreRelease)); i++ {
-		// a larger set of pre-release data has a higher precedence
+	return compareIdentifiers(a.PreRelease, b.PreRelease)
+}
+
+func compareIdentifiers(a []string, b []string) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		// a larger set of identifiers has a higher precedence
 		// (if all the preceding identifiers are equal)
-		if i < len(a.PreRelease) && i >= len(b.PreRelease) {
+		if i < len(a) && i >= len(b) {
 			return +1
 		}
-		if i < len(b.PreRelease) && i >= len(a.PreRelease) {
+		if i < len(b) && i >= len(a) {
 			return -1
 		}
 
-		if isDigits(a.PreRelease[i]) && isDigits(b.PreRelease[i]) {
+		if isDigits(a[i]) && isDigits(b[i]) {
 			// identifiers consisting only of digits are compared numerically
 
 			// if a digit string is longer, it has precedence
-			if len(a.PreRelease[i]) > len(b.PreRelease[i]) {
+			if len(a[i]) > len(b[i]) {
 				return +1
 			}
-			if len(a.PreRelease[i]) < len(b.PreRelease[i]) {
+			if len(a[i]) < len(b[i]) {
 				return -1
 			}
 
 			// for same length digit strings, compare strings digit by digit
-			for j := range a.PreRelease[i] {
-				result := strings.Compare(a.PreRelease[i][j:j+1], b.PreRelease[i][j:j+1])
+			for j := range a[i] {
+				result := strings.Compare(a[i][j:j+1], b[i][j:j+1])
 				if result != 0 {
 					return result
 				}
 			}
 		} else {
 			// identifiers with letters or hyphens are compared lexically in ASCII sort order
-			result := strings.Compare(a.PreRelease[i], b.PreRelease[i])
+			result := strings.Compare(a[i], b[i])
 			if result != 0 {
 				return result
 			}
@@ -264,3 +304,29 @@ func SortDesc(vers []Version) []Version {
 	})
 	return vers
 }
+
+// GroupBy splits a slice of Version structs into groups sharing the same key,
+// e.g. the major version. The versions of a group keep their order.
+func GroupBy(vers []Version, key func(Version) string) map[string][]Version {
+	groups := make(map[string][]Version)
+	for _, ver := range vers {
+		k := key(ver)
+		groups[k] = append(groups[k], ver)
+	}
+	return groups
+}
+
+// Latest returns the newest release in a slice of Version structs.
+// Pre-releases are ignored, ok is false if there is no release.
+func Latest(vers []Version) (latest Version, ok bool) {
+	for _, ver := range vers {
+		if !ver.IsRelease() {
+			continue
+		}
+		if !ok || ver.Newer(latest) {
+			latest = ver
+			ok = true
+		}
+	}
+	return latest, ok
+}
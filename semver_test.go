@@ -629,3 +629,79 @@ func TestSortDesc(t *testing.T) {
 		}
 	}
 }
+
+func TestLatest(t *testing.T) {
+	latest, ok := Latest(MustParseAll([]string{"1.0.0", "2.0.0-rc.1", "1.1.0", "0.9.0"}))
+	if !ok || latest.String() != "1.1.0" {
+		t.Errorf("unexpected latest = %s, ok = %v", latest.String(), ok)
+	}
+	_, ok = Latest(MustParseAll([]string{"2.0.0-rc.1"}))
+	if ok {
+		t.Error("should not find a release")
+	}
+}
+
+func TestVersion_Next(t *testing.T) {
+	tests := []struct {
+		input string
+		major string
+		minor string
+		patch string
+	}{
+		{input: "1.2.3", major: "2.0.0", minor: "1.3.0", patch: "1.2.4"},
+		{input: "0.0.0", major: "1.0.0", minor: "0.1.0", patch: "0.0.1"},
+		{input: "1.2.3-rc.1+build.5", major: "2.0.0", minor: "1.3.0", patch: "1.2.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v := MustParse(tt.input)
+			if got := v.NextMajor(); got.String() != tt.major {
+				t.Errorf("NextMajor() = %s, want %s", got.String(), tt.major)
+			}
+			if got := v.NextMinor(); got.String() != tt.minor {
+				t.Errorf("NextMinor() = %s, want %s", got.String(), tt.minor)
+			}
+			if got := v.NextPatch(); got.String() != tt.patch {
+				t.Errorf("NextPatch() = %s, want %s", got.String(), tt.patch)
+			}
+		})
+	}
+}
+
+func TestCompareBuild(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "1.0.0", b: "2.0.0+1", expected: -1},
+		{a: "1.0.0-rc.1+2", b: "1.0.0+1", expected: -1},
+		{a: "1.0.0", b: "1.0.0+1", expected: -1},
+		{a: "1.0.0+1", b: "1.0.0", expected: +1},
+		{a: "1.0.0+2", b: "1.0.0+10", expected: -1},
+		{a: "1.0.0+a", b: "1.0.0+b", expected: -1},
+		{a: "1.0.0+a.b", b: "1.0.0+a", expected: +1},
+		{a: "1.0.0+a.b", b: "1.0.0+a.b", expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.a, test.b), func(t *testing.T) {
+			result := CompareBuild(MustParse(test.a), MustParse(test.b))
+			if test.expected != result {
+				t.Errorf("unexpected result:\nexpected = %+v\nactual   = %+v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(MustParseAll([]string{"1.0.0", "2.1.0", "1.2.0-rc.1", "2.0.0"}), func(v Version) string {
+		return strconv.Itoa(v.Major)
+	})
+	expected := map[string][]Version{
+		"1": MustParseAll([]string{"1.0.0", "1.2.0-rc.1"}),
+		"2": MustParseAll([]string{"2.1.0", "2.0.0"}),
+	}
+	if !reflect.DeepEqual(expected, groups) {
+		t.Errorf("unexpected result:\nexpected = %v\nactual   = %v", expected, groups)
+	}
+}
@@ -0,0 +1,201 @@
+package semver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Support selects the maintained release lines of released versions,
+// implemented by SupportPolicy and EOLTable.
+type Support interface {
+	// Maintained returns the maintained release lines of versions, newest first.
+	Maintained(vers []Version) []Line
+}
+
+// SupportPolicy selects the maintained release lines, the newest minors
+// of the newest majors. Negative limits maintain everything.
+type SupportPolicy struct {
+	// newest majors that are maintained
+	Majors int
+	// newest minors of every maintained major
+	Minors int
+}
+
+// Line is a minor release line, like 1.2, as maintained on a release branch.
+type Line struct {
+	Major int
+	Minor int
+	// newest release of the line
+	Latest Version
+}
+
+// String will build and return the major.minor representation of Line.
+func (l *Line) String() string {
+	return strconv.Itoa(l.Major) + "." + strconv.Itoa(l.Minor)
+}
+
+// Backport is a maintained release line that receives a fix.
+type Backport struct {
+	Line Line
+	// patch version of the line shipping the fix
+	Next Version
+}
+
+// Lines returns the release lines of versions, newest first.
+// Pre-releases are ignored.
+func Lines(vers []Version) []Line {
+	return (&SupportPolicy{Majors: -1, Minors: -1}).Maintained(vers)
+}
+
+// Maintained returns the release lines of versions maintained according to the policy, newest first.
+// Pre-releases are ignored.
+func (p *SupportPolicy) Maintained(vers []Version) []Line {
+	var releases []Version
+	for _, ver := range vers {
+		if ver.IsRelease() {
+			releases = append(releases, ver)
+		}
+	}
+
+	var lines []Line
+	majors := GroupBy(releases, func(v Version) string { return strconv.Itoa(v.Major) })
+	for _, major := range newestGroups(majors, p.Majors) {
+		minors := GroupBy(major, func(v Version) string { return strconv.Itoa(v.Minor) })
+		for _, minor := range newestGroups(minors, p.Minors) {
+			lines = append(lines, Line{Major: minor[0].Major, Minor: minor[0].Minor, Latest: minor[0]})
+		}
+	}
+	return lines
+}
+
+// Backports returns the maintained release lines affected by a bug introduced in
+// version introduced, newest first. A line is affected if its newest release is
+// not older than introduced, the fix is shipped with the next patch of the line.
+func (p *SupportPolicy) Backports(vers []Version, introduced Version) []Backport {
+	var backports []Backport
+	for _, line := range p.Maintained(vers) {
+		if Compare(line.Latest, introduced) < 0 {
+			continue
+		}
+		backports = append(backports, Backport{Line: line, Next: line.Latest.NextPatch()})
+	}
+	return backports
+}
+
+var ErrEOLTable = errors.New("invalid eol table")
+
+var eolLineRegex = regexp.MustCompile("^(0|[1-9]\\d*)(?:\\.(0|[1-9]\\d*))?$")
+
+// EOLTable selects the maintained release lines by their end of life dates.
+type EOLTable struct {
+	// end of life of release lines by major.minor, like 1.2, or by major, like 1,
+	// lines without date are maintained
+	Dates map[string]time.Time
+	// date of the evaluation, lines reaching their end of life on it are not maintained
+	Now time.Time
+}
+
+// ParseEOLTable will attempt to read an EOLTable evaluated at now.
+// Every line holds a release line, major.minor or major, and its end of life
+// date formatted as yyyy-mm-dd. Empty lines and lines starting with # are ignored.
+//
+//	# line  eol
+//	1.2     2025-06-30
+//	0       2024-01-01
+//
+// ParseEOLTable might return semver.ErrEOLTable.
+func ParseEOLTable(r io.Reader, now time.Time) (*EOLTable, error) {
+	t := &EOLTable{Dates: make(map[string]time.Time), Now: now}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: line %d: %q", ErrEOLTable, n, line)
+		}
+		if !eolLineRegex.MatchString(fields[0]) {
+			return nil, fmt.Errorf("%w: line %d: invalid release line %q", ErrEOLTable, n, fields[0])
+		}
+		date, err := time.Parse(time.DateOnly, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrEOLTable, n, err)
+		}
+		t.Dates[fields[0]] = date
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Maintained returns the release lines of versions before their end of life, newest first.
+// Pre-releases are ignored.
+func (t *EOLTable) Maintained(vers []Version) []Line {
+	var lines []Line
+	for _, line := range Lines(vers) {
+		date, ok := t.Dates[line.String()]
+		if !ok {
+			date, ok = t.Dates[strconv.Itoa(line.Major)]
+		}
+		if !ok || t.Now.Before(date) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Status is the support status of a Version.
+type Status string
+
+const (
+	// the version is the newest release of a maintained line
+	StatusSupported Status = "supported"
+	// the line of the version is maintained, but a newer patch was released
+	StatusDeprecated Status = "deprecated"
+	// the line of the version is not maintained anymore
+	StatusEOL Status = "eol"
+)
+
+// SupportStatus is the support status of a Version and the version to upgrade to.
+type SupportStatus struct {
+	Status Status
+	// version to upgrade to, nil if the version is supported or there is no maintained newer line
+	Replacement *Version
+}
+
+// CheckSupport returns the support status of a Version among released versions.
+// Deprecated versions are replaced by the newest release of their line, versions
+// reaching their end of life by the newest release of the oldest maintained
+// line that is newer. Versions newer than all releases are supported.
+func CheckSupport(s Support, vers []Version, ver Version) SupportStatus {
+	lines := s.Maintained(vers)
+	for _, line := range lines {
+		if line.Major != ver.Major || line.Minor != ver.Minor {
+			continue
+		}
+		if Compare(ver, line.Latest) >= 0 {
+			return SupportStatus{Status: StatusSupported}
+		}
+		return SupportStatus{Status: StatusDeprecated, Replacement: &line.Latest}
+	}
+
+	if latest, ok := Latest(vers); !ok || Compare(ver, latest) > 0 {
+		return SupportStatus{Status: StatusSupported}
+	}
+	status := SupportStatus{Status: StatusEOL}
+	for _, line := range lines {
+		if Compare(line.Latest, ver) > 0 {
+			status.Replacement = &line.Latest
+		}
+	}
+	return status
+}
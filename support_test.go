@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var supportVersions = MustParseAll([]string{
+	"2.1.0", "2.0.1", "2.0.0", "2.2.0-rc.1",
+	"1.3.2", "1.3.1", "1.2.7", "1.2.5", "1.1.9",
+	"0.9.0",
+})
+
+func TestSupportPolicy_Maintained(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SupportPolicy
+		lines  string
+	}{
+		{name: "all", policy: SupportPolicy{Majors: -1, Minors: -1}, lines: "2.1:2.1.0 2.0:2.0.1 1.3:1.3.2 1.2:1.2.7 1.1:1.1.9 0.9:0.9.0"},
+		{name: "two minors of two majors", policy: SupportPolicy{Majors: 2, Minors: 2}, lines: "2.1:2.1.0 2.0:2.0.1 1.3:1.3.2 1.2:1.2.7"},
+		{name: "newest minor", policy: SupportPolicy{Majors: -1, Minors: 1}, lines: "2.1:2.1.0 1.3:1.3.2 0.9:0.9.0"},
+		{name: "none", policy: SupportPolicy{}, lines: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var strs []string
+			for _, line := range test.policy.Maintained(supportVersions) {
+				strs = append(strs, line.String()+":"+line.Latest.String())
+			}
+			if actual := strings.Join(strs, " "); actual != test.lines {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.lines, actual)
+			}
+		})
+	}
+
+	if lines := Lines(supportVersions); len(lines) != 6 {
+		t.Errorf("unexpected line count: %d", len(lines))
+	}
+}
+
+func TestSupportPolicy_Backports(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     SupportPolicy
+		introduced string
+		backports  string
+	}{
+		{name: "all lines since", policy: SupportPolicy{Majors: -1, Minors: -1}, introduced: "1.2.5", backports: "2.1:2.1.1 2.0:2.0.2 1.3:1.3.3 1.2:1.2.8"},
+		{name: "maintained lines", policy: SupportPolicy{Majors: 2, Minors: 1}, introduced: "1.2.5", backports: "2.1:2.1.1 1.3:1.3.3"},
+		{name: "unreleased line", policy: SupportPolicy{Majors: -1, Minors: -1}, introduced: "2.2.0-rc.1", backports: ""},
+		{name: "pre-release of a line", policy: SupportPolicy{Majors: -1, Minors: -1}, introduced: "2.1.0-rc.1", backports: "2.1:2.1.1"},
+		{name: "newer than all", policy: SupportPolicy{Majors: -1, Minors: -1}, introduced: "3.0.0", backports: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var strs []string
+			for _, b := range test.policy.Backports(supportVersions, MustParse(test.introduced)) {
+				strs = append(strs, b.Line.String()+":"+b.Next.String())
+			}
+			if actual := strings.Join(strs, " "); actual != test.backports {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.backports, actual)
+			}
+		})
+	}
+}
+
+func TestParseEOLTable(t *testing.T) {
+	table, err := ParseEOLTable(strings.NewReader("# line eol\n\n1.2 2025-06-30\n0   2024-01-01\n"), time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var strs []string
+	for _, line := range table.Maintained(supportVersions) {
+		strs = append(strs, line.String())
+	}
+	expected := "2.1 2.0 1.3 1.1"
+	if actual := strings.Join(strs, " "); actual != expected {
+		t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", expected, actual)
+	}
+
+	table.Now = time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)
+	if lines := table.Maintained(supportVersions); len(lines) != 5 {
+		t.Errorf("unexpected line count: %d", len(lines))
+	}
+}
+
+func TestParseEOLTableInvalids(t *testing.T) {
+	tests := []string{
+		"1.2",
+		"1.2 2025-06-31",
+		"1.2.3 2025-06-30",
+		"v1 2025-06-30",
+		"1.x 2025-06-30",
+		"1.2 2025-06-30 note",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseEOLTable(strings.NewReader(test), time.Now())
+			if !errors.Is(err, ErrEOLTable) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckSupport(t *testing.T) {
+	policy := &SupportPolicy{Majors: 2, Minors: 1}
+	table := &EOLTable{
+		Dates: map[string]time.Time{"1.2": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Now:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name        string
+		support     Support
+		version     string
+		status      Status
+		replacement string
+	}{
+		{name: "newest of line", support: policy, version: "2.1.0", status: StatusSupported},
+		{name: "newer patch", support: policy, version: "1.3.1", status: StatusDeprecated, replacement: "1.3.2"},
+		{name: "pre-release of line", support: policy, version: "1.3.2-rc.1", status: StatusDeprecated, replacement: "1.3.2"},
+		{name: "unmaintained minor", support: policy, version: "2.0.0", status: StatusEOL, replacement: "2.1.0"},
+		{name: "unmaintained major", support: policy, version: "0.9.0", status: StatusEOL, replacement: "1.3.2"},
+		{name: "unreleased", support: policy, version: "2.2.0-rc.2", status: StatusSupported},
+		{name: "eol date", support: table, version: "1.2.7", status: StatusEOL, replacement: "1.3.2"},
+		{name: "no eol date", support: table, version: "1.1.9", status: StatusSupported},
+		{name: "no maintained newer line", support: &SupportPolicy{}, version: "2.1.0", status: StatusEOL},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := CheckSupport(test.support, supportVersions, MustParse(test.version))
+			if status.Status != test.status {
+				t.Errorf("unexpected status:\nexpected = %s\nactual   = %s", test.status, status.Status)
+			}
+			replacement := ""
+			if status.Replacement != nil {
+				replacement = status.Replacement.String()
+			}
+			if replacement != test.replacement {
+				t.Errorf("unexpected replacement:\nexpected = %s\nactual   = %s", test.replacement, replacement)
+			}
+		})
+	}
+}
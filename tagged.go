@@ -0,0 +1,87 @@
+package semver
+
+// TaggedVersion is a Version carrying an arbitrary prefix, as found in
+// the tags of a monorepo (api/v1.4.0, web/v2.0.1) or plain v-prefixed tags.
+type TaggedVersion struct {
+	// everything in front of the version core, e.g. "api/v"
+	Prefix string
+
+	Version Version
+}
+
+// ParseTagged will attempt to split a tag into prefix and semver.Version.
+// The version is the longest valid semver suffix of the tag.
+//
+//	ParseTagged("api/v1.4.0") -> {Prefix: "api/v", Version: 1.4.0}
+//
+// ParseTagged might return semver.ErrInvalid.
+func ParseTagged(str string) (TaggedVersion, error) {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) || (i > 0 && isDigit(str[i-1])) {
+			continue
+		}
+		ver, err := Parse(str[i:])
+		if err == nil {
+			return TaggedVersion{Prefix: str[:i], Version: ver}, nil
+		}
+	}
+	return TaggedVersion{}, ErrInvalid
+}
+
+// MustParseTagged wraps ParseTagged and panics on error.
+func MustParseTagged(str string) TaggedVersion {
+	tv, err := ParseTagged(str)
+	if err != nil {
+		panic(err)
+	}
+	return tv
+}
+
+// ParseTaggedAll will attempt to convert a slice of strings to a slice of semver.TaggedVersion structs.
+//
+// ParseTaggedAll might return semver.ErrInvalid.
+func ParseTaggedAll(strs []string) ([]TaggedVersion, error) {
+	var tvs []TaggedVersion
+	for _, str := range strs {
+		tv, err := ParseTagged(str)
+		if err != nil {
+			return nil, err
+		}
+		tvs = append(tvs, tv)
+	}
+	return tvs, nil
+}
+
+// String will build and return the tag representation of TaggedVersion.
+func (tv *TaggedVersion) String() string {
+	return tv.Prefix + tv.Version.String()
+}
+
+// GroupByPrefix splits tagged versions into one stream per prefix.
+// Every stream is sorted in descending natural order.
+func GroupByPrefix(tvs []TaggedVersion) map[string][]Version {
+	streams := make(map[string][]Version)
+	for _, tv := range tvs {
+		streams[tv.Prefix] = append(streams[tv.Prefix], tv.Version)
+	}
+	for prefix := range streams {
+		streams[prefix] = SortDesc(streams[prefix])
+	}
+	return streams
+}
+
+// LatestByPrefix returns the newest release Version of every prefix.
+// Prefixes without any release are omitted.
+func LatestByPrefix(tvs []TaggedVersion) map[string]Version {
+	latest := make(map[string]Version)
+	for prefix, vers := range GroupByPrefix(tvs) {
+		if ver, ok := Latest(vers); ok {
+			latest[prefix] = ver
+		}
+	}
+	return latest
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTagged(t *testing.T) {
+	tests := []struct {
+		input   string
+		prefix  string
+		version string
+	}{
+		{input: "1.2.3", prefix: "", version: "1.2.3"},
+		{input: "v1.2.3", prefix: "v", version: "1.2.3"},
+		{input: "api/v1.4.0", prefix: "api/v", version: "1.4.0"},
+		{input: "web/v2.0.1-rc.1+build.5", prefix: "web/v", version: "2.0.1-rc.1+build.5"},
+		{input: "service-a/v11.0.0", prefix: "service-a/v", version: "11.0.0"},
+		{input: "tool2-v1.0.0", prefix: "tool2-v", version: "1.0.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tv, err := ParseTagged(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tv.Prefix != test.prefix {
+				t.Errorf("unexpected prefix:\nexpected = %q\nactual   = %q", test.prefix, tv.Prefix)
+			}
+			if tv.Version.String() != test.version {
+				t.Errorf("unexpected version:\nexpected = %s\nactual   = %s", test.version, tv.Version.String())
+			}
+			if tv.String() != test.input {
+				t.Errorf("non equal string format:\nexpected = %s\nactual   = %s", test.input, tv.String())
+			}
+		})
+	}
+}
+
+func TestParseTaggedInvalids(t *testing.T) {
+	tests := []string{
+		"",
+		"latest",
+		"api/v1.4",
+		"v01.2.3",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseTagged(test)
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupByPrefix(t *testing.T) {
+	tvs, err := ParseTaggedAll([]string{
+		"api/v1.4.0",
+		"web/v2.0.1",
+		"api/v1.10.0-rc.1",
+		"api/v1.5.0",
+		"web/v2.0.0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	streams := GroupByPrefix(tvs)
+	expected := map[string][]Version{
+		"api/v": MustParseAll([]string{"1.10.0-rc.1", "1.5.0", "1.4.0"}),
+		"web/v": MustParseAll([]string{"2.0.1", "2.0.0"}),
+	}
+	if !reflect.DeepEqual(expected, streams) {
+		t.Errorf("unexpected streams:\nexpected = %v\nactual   = %v", expected, streams)
+	}
+
+	latest := LatestByPrefix(tvs)
+	if len(latest) != 2 {
+		t.Fatalf("unexpected latest count: %d", len(latest))
+	}
+	if v := latest["api/v"]; v.String() != "1.5.0" {
+		t.Errorf("unexpected latest api version: %s", v.String())
+	}
+	if v := latest["web/v"]; v.String() != "2.0.1" {
+		t.Errorf("unexpected latest web version: %s", v.String())
+	}
+}
@@ -0,0 +1,193 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TagKind is the role of a container tag in a TagPlan.
+type TagKind string
+
+const (
+	// exact version including build metadata, see Version.ContainerTag
+	TagBuild TagKind = "build"
+	// exact version, e.g. 1.2.3
+	TagExact TagKind = "exact"
+	// floating tag of the minor line, e.g. 1.2
+	TagMinor TagKind = "minor"
+	// floating tag of the major line, e.g. 1
+	TagMajor TagKind = "major"
+	// floating tag of the newest release, e.g. latest
+	TagChannel TagKind = "channel"
+)
+
+// PreReleasePolicy selects the floating tags of pre-releases in a TagPlan.
+type PreReleasePolicy string
+
+const (
+	// only the exact version is tagged, e.g. 1.2.3-rc.1
+	PreReleaseExactOnly PreReleasePolicy = "exact-only"
+	// floating tags of the pre-release channel, the first pre-release identifier,
+	// e.g. 1.2.3-rc.1 -> 1.2-rc 1-rc rc. Pre-releases without channel, like 1.2.3-1,
+	// are tagged exact only.
+	PreReleaseChannelFloating PreReleasePolicy = "channel-floating"
+	// floating tags keep the whole pre-release, e.g. 1.2.3-rc.1 -> 1.2-rc.1 1-rc.1
+	PreReleaseFloating PreReleasePolicy = "floating"
+)
+
+// PreReleasePolicies are the valid values of TagPlan.PreRelease.
+var PreReleasePolicies = []PreReleasePolicy{PreReleaseExactOnly, PreReleaseChannelFloating, PreReleaseFloating}
+
+// Tag is a container tag of a Version.
+type Tag struct {
+	// rendered tag, e.g. v1.2-alpine
+	Name    string
+	Kind    TagKind
+	Version Version
+}
+
+// TagPlan describes the expansion of a Version to container tags,
+// the exact version followed by the floating minor, major and channel tags.
+//
+//	TagPlan{Prefix: "v", Variant: "alpine", Channel: "latest"}
+//	1.2.3 -> v1.2.3-alpine v1.2-alpine v1-alpine alpine
+//
+// Floating tags of pre-releases follow the PreRelease policy, pre-releases never
+// get the channel tag. Build metadata is dropped unless Build is set.
+type TagPlan struct {
+	// prepended to the version tags, e.g. v
+	Prefix string
+	// appended to all tags separated by "-", e.g. alpine
+	Variant string
+	// tag of the newest release, e.g. latest or stable, omitted if empty.
+	// The latest tag of a variant is the bare variant.
+	Channel string
+	// emit the exact version including build metadata in front
+	Build bool
+	// floating tags of pre-releases, semver.PreReleaseExactOnly if empty
+	PreRelease PreReleasePolicy
+	// omit the major tag of 0.x versions, they make no compatibility promise
+	NoMajorForZero bool
+	// already published versions, floating tags never move back to an older version
+	Published []Version
+}
+
+// Tags will attempt to expand a Version to container tags.
+// Floating tags are omitted if a published version of their line is newer.
+//
+// Tags might return semver.ErrContainerTag.
+func (p *TagPlan) Tags(ver Version) ([]Tag, error) {
+	var tags []Tag
+	add := func(kind TagKind, name string) error {
+		if err := checkContainerTag(name); err != nil {
+			return err
+		}
+		tags = append(tags, Tag{Name: name, Kind: kind, Version: ver})
+		return nil
+	}
+
+	major := strconv.Itoa(ver.Major)
+	minor := major + "." + strconv.Itoa(ver.Minor)
+	exact := minor + "." + strconv.Itoa(ver.Patch)
+
+	// pre-release of the exact and floating tags, the channel tag and
+	// the published versions on the same track, moving the floating tags
+	pre, float, floating, channel := "", "", true, p.Channel
+	track := func(pub Version) bool { return pub.IsRelease() }
+	if !ver.IsRelease() {
+		pre = "-" + strings.Join(ver.PreRelease, ".")
+		switch ch := preReleaseChannel(ver); {
+		case p.PreRelease == PreReleaseFloating:
+			float, channel = pre, ""
+			track = func(pub Version) bool { return true }
+		case p.PreRelease == PreReleaseChannelFloating && ch != "":
+			float, channel = "-"+ch, ch
+			track = func(pub Version) bool { return !pub.IsRelease() && preReleaseChannel(pub) == ch }
+		default:
+			floating, channel = false, ""
+		}
+	}
+
+	if p.Build && len(ver.Build) > 0 {
+		build := exact + pre + ContainerTagBuildSeparator + strings.Join(ver.Build, ".")
+		if err := add(TagBuild, p.versionTag(build)); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(TagExact, p.versionTag(exact+pre)); err != nil {
+		return nil, err
+	}
+	if floating && p.newest(ver, track, func(pub Version) bool { return pub.Major == ver.Major && pub.Minor == ver.Minor }) {
+		if err := add(TagMinor, p.versionTag(minor+float)); err != nil {
+			return nil, err
+		}
+	}
+	if floating && !(p.NoMajorForZero && ver.Major == 0) && p.newest(ver, track, func(pub Version) bool { return pub.Major == ver.Major }) {
+		if err := add(TagMajor, p.versionTag(major+float)); err != nil {
+			return nil, err
+		}
+	}
+	if channel != "" && p.newest(ver, track, func(pub Version) bool { return true }) {
+		name := channel + "-" + p.Variant
+		if p.Variant == "" {
+			name = channel
+		} else if channel == "latest" && ver.IsRelease() {
+			name = p.Variant
+		}
+		if err := add(TagChannel, name); err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// ParseTag will attempt to decode an exact or build tag rendered by Tags back
+// into a semver.Version. Prefix and variant are stripped before the tag is
+// decoded with ParseContainerTag, so the variant is never mistaken for
+// pre-release or build metadata.
+//
+//	TagPlan{Variant: "alpine", Build: true}
+//	1.2.3_git.a1b2c3-alpine -> 1.2.3+git.a1b2c3
+//
+// ParseTag might return semver.ErrContainerTag, semver.ErrInvalid,
+// strconv.ErrRange or strconv.ErrSyntax.
+func (p *TagPlan) ParseTag(tag string) (Version, error) {
+	str, ok := strings.CutPrefix(tag, p.Prefix)
+	if ok && p.Variant != "" {
+		str, ok = strings.CutSuffix(str, "-"+p.Variant)
+	}
+	if !ok {
+		return Version{}, fmt.Errorf("%w: %q lacks prefix %q or variant %q", ErrContainerTag, tag, p.Prefix, p.Variant)
+	}
+	return ParseContainerTag(str)
+}
+
+// preReleaseChannel returns the first pre-release identifier unless it is numeric,
+// e.g. rc of 1.2.3-rc.1.
+func preReleaseChannel(ver Version) string {
+	if ver.IsRelease() || isDigits(ver.PreRelease[0]) {
+		return ""
+	}
+	return ver.PreRelease[0]
+}
+
+// versionTag decorates a version with prefix and variant.
+func (p *TagPlan) versionTag(str string) string {
+	if p.Variant != "" {
+		str += "-" + p.Variant
+	}
+	return p.Prefix + str
+}
+
+// newest reports if ver is at least as new as every published version
+// of its track matching the line of a floating tag. Published pre-releases
+// are not on the track of releases, they do not move floating tags.
+func (p *TagPlan) newest(ver Version, track func(Version) bool, line func(Version) bool) bool {
+	for _, pub := range p.Published {
+		if track(pub) && line(pub) && Compare(pub, ver) > 0 {
+			return false
+		}
+	}
+	return true
+}
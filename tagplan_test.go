@@ -0,0 +1,120 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTagPlan_Tags(t *testing.T) {
+	published := MustParseAll([]string{"1.1.8", "1.2.0", "1.3.0-rc.1", "2.0.0"})
+	tests := []struct {
+		name    string
+		plan    TagPlan
+		version string
+		tags    string
+	}{
+		{name: "default", plan: TagPlan{}, version: "1.2.3", tags: "exact:1.2.3 minor:1.2 major:1"},
+		{name: "pre-release", plan: TagPlan{Channel: "latest"}, version: "1.2.3-rc.1", tags: "exact:1.2.3-rc.1"},
+		{name: "pre-release exact only", plan: TagPlan{PreRelease: PreReleaseExactOnly}, version: "1.2.3-rc.1", tags: "exact:1.2.3-rc.1"},
+		{name: "pre-release floating", plan: TagPlan{PreRelease: PreReleaseFloating, Channel: "latest"}, version: "1.2.3-rc.1", tags: "exact:1.2.3-rc.1 minor:1.2-rc.1 major:1-rc.1"},
+		{name: "pre-release channel", plan: TagPlan{PreRelease: PreReleaseChannelFloating, Channel: "latest"}, version: "1.2.3-rc.1", tags: "exact:1.2.3-rc.1 minor:1.2-rc major:1-rc channel:rc"},
+		{name: "pre-release channel variant", plan: TagPlan{PreRelease: PreReleaseChannelFloating, Prefix: "v", Variant: "alpine"}, version: "1.2.3-beta.2", tags: "exact:v1.2.3-beta.2-alpine minor:v1.2-beta-alpine major:v1-beta-alpine channel:beta-alpine"},
+		{name: "pre-release without channel", plan: TagPlan{PreRelease: PreReleaseChannelFloating}, version: "1.2.3-1", tags: "exact:1.2.3-1"},
+		{name: "pre-release channel published", plan: TagPlan{PreRelease: PreReleaseChannelFloating, Published: published}, version: "1.2.5-rc.1", tags: "exact:1.2.5-rc.1 minor:1.2-rc"},
+		{name: "pre-release channel published release", plan: TagPlan{PreRelease: PreReleaseChannelFloating, Published: published}, version: "1.3.0-rc.2", tags: "exact:1.3.0-rc.2 minor:1.3-rc major:1-rc channel:rc"},
+		{name: "pre-release floating published", plan: TagPlan{PreRelease: PreReleaseFloating, Published: published}, version: "1.3.0-rc.2", tags: "exact:1.3.0-rc.2 minor:1.3-rc.2 major:1-rc.2"},
+		{name: "zero", plan: TagPlan{}, version: "0.4.1", tags: "exact:0.4.1 minor:0.4 major:0"},
+		{name: "no major for zero", plan: TagPlan{NoMajorForZero: true, Channel: "latest"}, version: "0.4.1", tags: "exact:0.4.1 minor:0.4 channel:latest"},
+		{name: "no major for zero pre-release", plan: TagPlan{NoMajorForZero: true, PreRelease: PreReleaseChannelFloating}, version: "0.4.1-rc.1", tags: "exact:0.4.1-rc.1 minor:0.4-rc channel:rc"},
+		{name: "no major for one", plan: TagPlan{NoMajorForZero: true}, version: "1.0.0", tags: "exact:1.0.0 minor:1.0 major:1"},
+		{name: "prefix", plan: TagPlan{Prefix: "v"}, version: "1.2.3", tags: "exact:v1.2.3 minor:v1.2 major:v1"},
+		{name: "channel", plan: TagPlan{Channel: "stable"}, version: "1.2.3", tags: "exact:1.2.3 minor:1.2 major:1 channel:stable"},
+		{name: "variant", plan: TagPlan{Variant: "alpine", Channel: "latest"}, version: "1.2.3", tags: "exact:1.2.3-alpine minor:1.2-alpine major:1-alpine channel:alpine"},
+		{name: "variant channel", plan: TagPlan{Prefix: "v", Variant: "alpine", Channel: "stable"}, version: "1.2.3", tags: "exact:v1.2.3-alpine minor:v1.2-alpine major:v1-alpine channel:stable-alpine"},
+		{name: "build", plan: TagPlan{Build: true, Variant: "alpine"}, version: "1.2.3+git.a1b2", tags: "build:1.2.3_git.a1b2-alpine exact:1.2.3-alpine minor:1.2-alpine major:1-alpine"},
+		{name: "build dropped", plan: TagPlan{}, version: "1.2.3+git.a1b2", tags: "exact:1.2.3 minor:1.2 major:1"},
+		{name: "published older line", plan: TagPlan{Channel: "latest", Published: published}, version: "1.1.9", tags: "exact:1.1.9 minor:1.1"},
+		{name: "published pre-release", plan: TagPlan{Published: published}, version: "1.2.2", tags: "exact:1.2.2 minor:1.2 major:1"},
+		{name: "published newest", plan: TagPlan{Channel: "latest", Published: published}, version: "2.1.0", tags: "exact:2.1.0 minor:2.1 major:2 channel:latest"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tags, err := test.plan.Tags(MustParse(test.version))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var strs []string
+			for _, tag := range tags {
+				strs = append(strs, string(tag.Kind)+":"+tag.Name)
+				if tag.Version.String() != test.version {
+					t.Errorf("unexpected version:\nexpected = %s\nactual   = %s", test.version, tag.Version.String())
+				}
+			}
+			if actual := strings.Join(strs, " "); actual != test.tags {
+				t.Errorf("unexpected result:\nexpected = %s\nactual   = %s", test.tags, actual)
+			}
+		})
+	}
+}
+
+func TestTagPlan_TagsInvalids(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    TagPlan
+		version string
+	}{
+		{name: "prefix charset", plan: TagPlan{Prefix: "v/"}, version: "1.2.3"},
+		{name: "variant charset", plan: TagPlan{Variant: "alpine+musl"}, version: "1.2.3"},
+		{name: "channel charset", plan: TagPlan{Channel: ".latest"}, version: "1.2.3"},
+		{name: "length", plan: TagPlan{Variant: strings.Repeat("a", ContainerTagMaxLength)}, version: "1.2.3"},
+		{name: "build length", plan: TagPlan{Build: true}, version: "1.2.3+" + strings.Repeat("a", ContainerTagMaxLength)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.plan.Tags(MustParse(test.version))
+			if !errors.Is(err, ErrContainerTag) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTagPlan_ParseTag(t *testing.T) {
+	plan := TagPlan{Prefix: "v", Variant: "alpine", Build: true, PreRelease: PreReleaseFloating}
+	ver := MustParse("1.2.3-rc.1+git.a1b2c3")
+	tags, err := plan.Tags(ver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range tags {
+		t.Run(tag.Name, func(t *testing.T) {
+			actual, err := plan.ParseTag(tag.Name)
+			switch tag.Kind {
+			case TagBuild:
+				if err != nil || actual.String() != ver.String() {
+					t.Errorf("unexpected result:\nexpected = %s\nactual   = %s (%v)", ver.String(), actual.String(), err)
+				}
+			case TagExact:
+				if err != nil || actual.String() != "1.2.3-rc.1" {
+					t.Errorf("unexpected result:\nexpected = %s\nactual   = %s (%v)", "1.2.3-rc.1", actual.String(), err)
+				}
+			default:
+				if err == nil {
+					t.Errorf("unexpected result = %s", actual.String())
+				}
+			}
+		})
+	}
+}
+
+func TestTagPlan_ParseTagInvalids(t *testing.T) {
+	plan := TagPlan{Prefix: "v", Variant: "alpine"}
+	for _, tag := range []string{"1.2.3-alpine", "v1.2.3", "v1.2.3-slim", "v1.2.3_a+b-alpine"} {
+		t.Run(tag, func(t *testing.T) {
+			if _, err := plan.ParseTag(tag); !errors.Is(err, ErrContainerTag) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
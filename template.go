@@ -0,0 +1,164 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Template describes the layout of a string that embeds a version,
+// like an artifact filename or a git ref.
+//
+//	{name}-{version}-{os}-{arch}.tar.gz
+//	refs/tags/{component}/v{version}
+//
+// Every template contains exactly one {version} placeholder.
+// All other placeholders are captured as named fields.
+type Template struct {
+	str    string
+	parts  []templatePart
+	fields []string
+	regex  *regexp.Regexp
+}
+
+type templatePart struct {
+	literal string
+	field   string
+}
+
+const versionField = "version"
+
+var ErrTemplate = errors.New("invalid template")
+var ErrNoMatch = errors.New("string does not match template")
+
+var fieldRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// ParseTemplate will attempt to compile a template string to a semver.Template.
+//
+// ParseTemplate might return semver.ErrTemplate.
+func ParseTemplate(str string) (*Template, error) {
+	t := &Template{str: str}
+
+	seen := make(map[string]bool)
+	rest := str
+	for len(rest) > 0 {
+		open := strings.IndexByte(rest, '{')
+		if open == -1 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			break
+		}
+		if open > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unclosed placeholder in %q", ErrTemplate, str)
+		}
+		field := rest[open+1 : open+end]
+		if !fieldRegex.MatchString(field) {
+			return nil, fmt.Errorf("%w: invalid placeholder %q", ErrTemplate, field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("%w: duplicate placeholder %q", ErrTemplate, field)
+		}
+		if len(t.parts) > 0 && t.parts[len(t.parts)-1].field != "" {
+			return nil, fmt.Errorf("%w: adjacent placeholders in %q", ErrTemplate, str)
+		}
+		seen[field] = true
+		t.parts = append(t.parts, templatePart{field: field})
+		if field != versionField {
+			t.fields = append(t.fields, field)
+		}
+		rest = rest[open+end+1:]
+	}
+	if !seen[versionField] {
+		return nil, fmt.Errorf("%w: missing {%s} placeholder in %q", ErrTemplate, versionField, str)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("^")
+	for _, part := range t.parts {
+		switch part.field {
+		case "":
+			sb.WriteString(regexp.QuoteMeta(part.literal))
+		case versionField:
+			sb.WriteString("(?P<" + versionField + ">" + pattern + ")")
+		default:
+			sb.WriteString("(?P<" + part.field + ">.+?)")
+		}
+	}
+	sb.WriteString("$")
+	t.regex = regexp.MustCompile(sb.String())
+
+	return t, nil
+}
+
+// MustParseTemplate wraps ParseTemplate and panics on error.
+func MustParseTemplate(str string) *Template {
+	t, err := ParseTemplate(str)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Extract will attempt to match a string against the template and returns
+// the embedded Version and the captured fields.
+//
+//	t := MustParseTemplate("{name}-{version}-{os}-{arch}.tar.gz")
+//	t.Extract("myapp-1.2.3-rc.1-linux-amd64.tar.gz")
+//	-> 1.2.3-rc.1, map[arch:amd64 name:myapp os:linux]
+//
+// Extract might return semver.ErrNoMatch, semver.ErrInvalid, strconv.ErrRange or strconv.ErrSyntax.
+func (t *Template) Extract(str string) (Version, map[string]string, error) {
+	m := t.regex.FindStringSubmatch(str)
+	if m == nil {
+		return Version{}, nil, ErrNoMatch
+	}
+
+	ver, err := Parse(m[t.regex.SubexpIndex(versionField)])
+	if err != nil {
+		return Version{}, nil, err
+	}
+
+	fields := make(map[string]string, len(t.fields))
+	for _, field := range t.fields {
+		fields[field] = m[t.regex.SubexpIndex(field)]
+	}
+
+	return ver, fields, nil
+}
+
+// Render will build the string representation of Version and fields
+// following the template. Render is the reverse operation of Extract.
+//
+// Render might return semver.ErrTemplate if a field is missing.
+func (t *Template) Render(ver Version, fields map[string]string) (string, error) {
+	sb := strings.Builder{}
+	for _, part := range t.parts {
+		switch part.field {
+		case "":
+			sb.WriteString(part.literal)
+		case versionField:
+			sb.WriteString(ver.String())
+		default:
+			val, ok := fields[part.field]
+			if !ok || val == "" {
+				return "", fmt.Errorf("%w: missing value for {%s}", ErrTemplate, part.field)
+			}
+			sb.WriteString(val)
+		}
+	}
+	return sb.String(), nil
+}
+
+// Fields returns the names of all placeholders, except {version}, in order of appearance.
+func (t *Template) Fields() []string {
+	return append([]string(nil), t.fields...)
+}
+
+// String returns the template string the Template was parsed from.
+func (t *Template) String() string {
+	return t.str
+}
@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTemplate_Extract(t *testing.T) {
+	tests := []struct {
+		template string
+		input    string
+		version  string
+		fields   map[string]string
+	}{
+		{
+			template: "{name}-{version}-{os}-{arch}.tar.gz",
+			input:    "myapp-1.2.3-rc.1-linux-amd64.tar.gz",
+			version:  "1.2.3-rc.1",
+			fields:   map[string]string{"name": "myapp", "os": "linux", "arch": "amd64"},
+		},
+		{
+			template: "{name}-{version}-{os}-{arch}.tar.gz",
+			input:    "my-app-1.2.3-linux-amd64.tar.gz",
+			version:  "1.2.3",
+			fields:   map[string]string{"name": "my-app", "os": "linux", "arch": "amd64"},
+		},
+		{
+			template: "{name}-{version}-{os}-{arch}.tar.gz",
+			input:    "myapp-1.2.3+build.5-darwin-arm64.tar.gz",
+			version:  "1.2.3+build.5",
+			fields:   map[string]string{"name": "myapp", "os": "darwin", "arch": "arm64"},
+		},
+		{
+			template: "refs/tags/{component}/v{version}",
+			input:    "refs/tags/service-a/v1.2.3",
+			version:  "1.2.3",
+			fields:   map[string]string{"component": "service-a"},
+		},
+		{
+			template: "v{version}",
+			input:    "v0.1.0-alpha",
+			version:  "0.1.0-alpha",
+			fields:   map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tmpl, err := ParseTemplate(test.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			ver, fields, err := tmpl.Extract(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ver.String() != test.version {
+				t.Errorf("unexpected version:\nexpected = %s\nactual   = %s", test.version, ver.String())
+			}
+			if !reflect.DeepEqual(test.fields, fields) {
+				t.Errorf("unexpected fields:\nexpected = %v\nactual   = %v", test.fields, fields)
+			}
+			rendered, err := tmpl.Render(ver, fields)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if rendered != test.input {
+				t.Errorf("non equal render:\nexpected = %s\nactual   = %s", test.input, rendered)
+			}
+		})
+	}
+}
+
+func TestTemplate_ExtractNoMatch(t *testing.T) {
+	tests := []struct {
+		template string
+		input    string
+	}{
+		{template: "{name}-{version}.tar.gz", input: "myapp-1.2.3.zip"},
+		{template: "refs/tags/{component}/v{version}", input: "refs/heads/main"},
+		{template: "v{version}", input: "v1.2"},
+		{template: "v{version}", input: "1.2.3"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, _, err := MustParseTemplate(test.template).Extract(test.input)
+			if !errors.Is(err, ErrNoMatch) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseTemplateInvalids(t *testing.T) {
+	tests := []string{
+		"",
+		"{name}.tar.gz",
+		"{version}-{version}",
+		"{name}-{version}-{name}",
+		"{name{version}",
+		"{name}-{version",
+		"{}-{version}",
+		"{1st}-{version}",
+		"{name}{version}",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseTemplate(test)
+			if !errors.Is(err, ErrTemplate) {
+				t.Errorf("unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplate_RenderMissingField(t *testing.T) {
+	tmpl := MustParseTemplate("{name}-{version}-{os}.zip")
+	_, err := tmpl.Render(MustParse("1.0.0"), map[string]string{"name": "myapp"})
+	if !errors.Is(err, ErrTemplate) {
+		t.Errorf("unexpected error = %v", err)
+	}
+}